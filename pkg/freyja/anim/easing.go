@@ -0,0 +1,78 @@
+package anim
+
+// Easing maps the linear progress of an animation in [0, 1]
+// to the eased progress, which starts at 0 and ends at 1.
+type Easing func(t float32) float32
+
+// Linear moves at constant speed.
+func Linear(t float32) float32 {
+	return t
+}
+
+// EaseIn starts slowly and accelerates.
+func EaseIn(t float32) float32 {
+	return t * t * t
+}
+
+// EaseOut starts fast and decelerates.
+func EaseOut(t float32) float32 {
+	t = 1 - t
+	return 1 - t*t*t
+}
+
+// EaseInOut accelerates in the first half and decelerates in the second.
+func EaseInOut(t float32) float32 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	t = -2*t + 2
+	return 1 - t*t*t/2
+}
+
+// CubicBezier returns an easing following the cubic Bézier curve
+// from (0, 0) to (1, 1) with the control points (x1, y1) and (x2, y2),
+// the same way as the cubic-bezier() timing function of CSS.
+//
+// x1 and x2 must be within [0, 1].
+func CubicBezier(x1, y1, x2, y2 float32) Easing {
+	bezier := func(a, b, t float32) float32 {
+		return 3*a*(1-t)*(1-t)*t + 3*b*(1-t)*t*t + t*t*t
+	}
+	slope := func(a, b, t float32) float32 {
+		return 3*a*(1-t)*(1-t) + 6*(b-a)*(1-t)*t + 3*(1-b)*t*t
+	}
+	return func(x float32) float32 {
+		if x <= 0 || x >= 1 {
+			return x
+		}
+		// Find t at x with Newton's method and fall back to bisection
+		// where the curve is too flat for it.
+		t := x
+		for i := 0; i < 8; i++ {
+			d := slope(x1, x2, t)
+			if d > -1e-6 && d < 1e-6 {
+				break
+			}
+			t -= (bezier(x1, x2, t) - x) / d
+		}
+		if t < 0 || t > 1 || abs(bezier(x1, x2, t)-x) > 1e-4 {
+			low, high := float32(0), float32(1)
+			for i := 0; i < 32; i++ {
+				t = (low + high) / 2
+				if bezier(x1, x2, t) < x {
+					low = t
+				} else {
+					high = t
+				}
+			}
+		}
+		return bezier(y1, y2, t)
+	}
+}
+
+func abs(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
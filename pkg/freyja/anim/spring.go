@@ -0,0 +1,93 @@
+package anim
+
+import (
+	"math"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+)
+
+// Spring moves a value towards its target as if they were
+// connected with a damped spring, keeping the velocity
+// when the target changes in the middle of a motion.
+type Spring struct {
+	Stiffness float32 // Stiffness is the force of the spring per unit of distance.
+	Damping   float32 // Damping is the friction per unit of velocity, critical damping if zero.
+	Precision float32 // Precision is the distance and the velocity at which the motion stops, 0.001 if zero.
+
+	value    float32
+	velocity float32
+	last     time.Time
+	placed   bool
+}
+
+// maxStep is the longest step of the simulation.
+const maxStep = time.Second / 120
+
+// Animate moves the value towards the target and returns it.
+//
+// The first Animate places the value at the target right away.
+func (s *Spring) Animate(gtx layout.Context, target float32) float32 {
+	if !s.placed || s.Stiffness <= 0 {
+		s.Set(target)
+		return target
+	}
+	if s.value == target && s.velocity == 0 {
+		s.last = time.Time{}
+		return s.value
+	}
+	if s.last.IsZero() {
+		s.last = gtx.Now
+	}
+	var (
+		elapsed   = gtx.Now.Sub(s.last)
+		damping   = s.Damping
+		precision = s.Precision
+	)
+	if damping == 0 {
+		damping = 2 * float32(math.Sqrt(float64(s.Stiffness)))
+	}
+	if precision == 0 {
+		precision = 0.001
+	}
+	// Integrate in short steps to stay stable on slow frames.
+	for elapsed > 0 {
+		step := elapsed
+		if step > maxStep {
+			step = maxStep
+		}
+		elapsed -= step
+		var (
+			dt    = float32(step.Seconds())
+			force = -s.Stiffness*(s.value-target) - damping*s.velocity
+		)
+		s.velocity += force * dt
+		s.value += s.velocity * dt
+	}
+	s.last = gtx.Now
+	if abs(s.value-target) < precision && abs(s.velocity) < precision {
+		s.value, s.velocity = target, 0
+		s.last = time.Time{}
+		return s.value
+	}
+	op.InvalidateOp{}.Add(gtx.Ops)
+	return s.value
+}
+
+// Set places the value at the target and stops the motion.
+func (s *Spring) Set(target float32) {
+	s.value, s.velocity = target, 0
+	s.last = time.Time{}
+	s.placed = true
+}
+
+// Value returns the value as of the last Animate.
+func (s *Spring) Value() float32 {
+	return s.value
+}
+
+// Animating reports whether the value is still moving.
+func (s *Spring) Animating() bool {
+	return s.velocity != 0 || !s.last.IsZero()
+}
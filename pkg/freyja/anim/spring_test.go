@@ -0,0 +1,28 @@
+package anim_test
+
+import (
+	"testing"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+	"github.com/widetape/freyja/pkg/freyja/anim"
+)
+
+func TestSpring_Animate(t *testing.T) {
+	var (
+		gtx    = layout.Context{Ops: new(op.Ops), Now: time.Unix(1000, 0)}
+		spring = anim.Spring{Stiffness: 200}
+	)
+	spring.Animate(gtx, 0)
+	for i := 0; i < 120 && (i == 0 || spring.Animating()); i++ {
+		v := spring.Animate(gtx, 10)
+		if v > 10 {
+			t.Fatalf("critically damped spring overshot to %v", v)
+		}
+		gtx.Now = gtx.Now.Add(time.Second / 60)
+	}
+	if spring.Animating() || spring.Value() != 10 {
+		t.Fatalf("spring did not settle, it's at %v", spring.Value())
+	}
+}
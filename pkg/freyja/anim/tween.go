@@ -0,0 +1,150 @@
+// Package anim animates values of freyja widgets over time.
+//
+// Animations are driven by layout.Context: they take the time of
+// the frame from Now and request the next frame with op.InvalidateOp
+// only while they are moving, so a widget just declares the target of
+// an animation on every Layout and uses the returned value.
+package anim
+
+import (
+	"image"
+	"image/color"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/unit"
+)
+
+// Lerp interpolates between a and b, where t is 0 at a and 1 at b.
+type Lerp[T any] func(a, b T, t float32) T
+
+// Tween moves a value towards its target over a duration.
+//
+// Tweens of float32, color.NRGBA, image.Point, image.Rectangle
+// and unit.Dp interpolate without Lerp. Tweens of other types
+// without Lerp jump to their targets.
+type Tween[T comparable] struct {
+	Duration time.Duration // Duration is how long the value takes to reach a new target.
+	Easing   Easing        // Easing is the curve of the animation, Linear if nil.
+	Lerp     Lerp[T]       // Lerp interpolates the value, other types jump to the target without it.
+
+	from, to, value T
+	start           time.Time
+	placed          bool
+}
+
+// Animate moves the value towards the target and returns it.
+//
+// The first Animate places the value at the target right away,
+// and a target that changes in the middle of an animation
+// starts a new one from the current value.
+func (tw *Tween[T]) Animate(gtx layout.Context, target T) T {
+	if !tw.placed || tw.Duration <= 0 {
+		tw.Set(target)
+		return target
+	}
+	if target != tw.to {
+		tw.from, tw.to = tw.value, target
+		tw.start = gtx.Now
+	}
+	if tw.value == tw.to {
+		return tw.value
+	}
+	progress := float32(gtx.Now.Sub(tw.start)) / float32(tw.Duration)
+	if progress >= 1 {
+		tw.value = tw.to
+		return tw.value
+	}
+	if tw.Easing != nil {
+		progress = tw.Easing(progress)
+	}
+	value, ok := tw.lerp(tw.from, tw.to, progress)
+	if !ok {
+		tw.value = tw.to
+		return tw.value
+	}
+	tw.value = value
+	op.InvalidateOp{}.Add(gtx.Ops)
+	return tw.value
+}
+
+// Set places the value at the target without animation.
+func (tw *Tween[T]) Set(target T) {
+	tw.from, tw.to, tw.value = target, target, target
+	tw.placed = true
+}
+
+// Value returns the value as of the last Animate.
+func (tw *Tween[T]) Value() T {
+	return tw.value
+}
+
+// Animating reports whether the value has not reached its target yet.
+func (tw *Tween[T]) Animating() bool {
+	return tw.value != tw.to
+}
+
+// lerp interpolates with Lerp or the default interpolation of T.
+// It reports false if T has no default interpolation.
+func (tw *Tween[T]) lerp(a, b T, t float32) (T, bool) {
+	if tw.Lerp != nil {
+		return tw.Lerp(a, b, t), true
+	}
+	var value any
+	switch a := any(a).(type) {
+	case float32:
+		value = Float32(a, any(b).(float32), t)
+	case color.NRGBA:
+		value = NRGBA(a, any(b).(color.NRGBA), t)
+	case image.Point:
+		value = Point(a, any(b).(image.Point), t)
+	case image.Rectangle:
+		value = Rectangle(a, any(b).(image.Rectangle), t)
+	case unit.Dp:
+		value = Dp(a, any(b).(unit.Dp), t)
+	default:
+		return b, false
+	}
+	return value.(T), true
+}
+
+// Float32 interpolates between float32 values.
+func Float32(a, b float32, t float32) float32 {
+	return a + (b-a)*t
+}
+
+// NRGBA interpolates between colors channel by channel.
+func NRGBA(a, b color.NRGBA, t float32) color.NRGBA {
+	channel := func(a, b uint8) uint8 {
+		return uint8(Float32(float32(a), float32(b), t) + 0.5)
+	}
+	return color.NRGBA{
+		R: channel(a.R, b.R),
+		G: channel(a.G, b.G),
+		B: channel(a.B, b.B),
+		A: channel(a.A, b.A),
+	}
+}
+
+// Point interpolates between points rounding to the nearest one.
+func Point(a, b image.Point, t float32) image.Point {
+	coordinate := func(a, b int) int {
+		v := Float32(float32(a), float32(b), t)
+		if v < 0 {
+			return int(v - 0.5)
+		}
+		return int(v + 0.5)
+	}
+	return image.Pt(coordinate(a.X, b.X), coordinate(a.Y, b.Y))
+}
+
+// Rectangle interpolates between rectangles corner by corner.
+func Rectangle(a, b image.Rectangle, t float32) image.Rectangle {
+	return image.Rectangle{Min: Point(a.Min, b.Min, t), Max: Point(a.Max, b.Max, t)}
+}
+
+// Dp interpolates between lengths.
+func Dp(a, b unit.Dp, t float32) unit.Dp {
+	return unit.Dp(Float32(float32(a), float32(b), t))
+}
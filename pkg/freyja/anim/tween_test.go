@@ -0,0 +1,98 @@
+package anim_test
+
+import (
+	"image/color"
+	"testing"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+	"github.com/widetape/freyja/pkg/freyja/anim"
+)
+
+func TestTween_Animate(t *testing.T) {
+	var (
+		start = time.Unix(1000, 0)
+		gtx   = layout.Context{Ops: new(op.Ops), Now: start}
+		tween = anim.Tween[float32]{Duration: time.Second}
+	)
+	if v := tween.Animate(gtx, 1); v != 1 {
+		t.Fatalf("first Animate: got %v, want 1", v)
+	}
+	if v := tween.Animate(gtx, 3); v != 1 {
+		t.Fatalf("Animate at start: got %v, want 1", v)
+	}
+	gtx.Now = start.Add(time.Second / 2)
+	if v := tween.Animate(gtx, 3); v != 2 {
+		t.Fatalf("Animate at half: got %v, want 2", v)
+	}
+	if !tween.Animating() {
+		t.Fatal("tween stopped before the end")
+	}
+	gtx.Now = start.Add(time.Second)
+	if v := tween.Animate(gtx, 3); v != 3 {
+		t.Fatalf("Animate at end: got %v, want 3", v)
+	}
+	if tween.Animating() {
+		t.Fatal("tween did not stop at the end")
+	}
+}
+
+func TestTween_NRGBA(t *testing.T) {
+	var (
+		start = time.Unix(1000, 0)
+		gtx   = layout.Context{Ops: new(op.Ops), Now: start}
+		tween = anim.Tween[color.NRGBA]{Duration: time.Second}
+	)
+	tween.Set(color.NRGBA{})
+	tween.Animate(gtx, color.NRGBA{R: 0xFF, A: 0xFF})
+	gtx.Now = start.Add(time.Second / 2)
+	want := color.NRGBA{R: 0x80, A: 0x80}
+	if v := tween.Animate(gtx, color.NRGBA{R: 0xFF, A: 0xFF}); v != want {
+		t.Fatalf("got %v, want %v", v, want)
+	}
+}
+
+func TestTween_WithoutLerp(t *testing.T) {
+	type state struct{ step int }
+	var (
+		start = time.Unix(1000, 0)
+		gtx   = layout.Context{Ops: new(op.Ops), Now: start}
+		tween = anim.Tween[state]{Duration: time.Second}
+	)
+	tween.Animate(gtx, state{1})
+	gtx.Now = start.Add(time.Second / 2)
+	tween.Animate(gtx, state{2})
+	if v := tween.Animate(gtx, state{2}); v != (state{2}) {
+		t.Fatalf("got %v, want the target", v)
+	}
+	if tween.Animating() {
+		t.Fatal("tween without Lerp is animating")
+	}
+}
+
+func TestEasing(t *testing.T) {
+	easings := map[string]anim.Easing{
+		"Linear":      anim.Linear,
+		"EaseIn":      anim.EaseIn,
+		"EaseOut":     anim.EaseOut,
+		"EaseInOut":   anim.EaseInOut,
+		"CubicBezier": anim.CubicBezier(0.25, 0.1, 0.25, 1),
+	}
+	for name, easing := range easings {
+		if v := easing(0); v != 0 {
+			t.Errorf("%s(0) = %v, want 0", name, v)
+		}
+		if v := easing(1); v != 1 {
+			t.Errorf("%s(1) = %v, want 1", name, v)
+		}
+		for x := float32(0.1); x < 1; x += 0.1 {
+			if easing(x) < easing(x-0.1) {
+				t.Errorf("%s is not monotonic at %v", name, x)
+			}
+		}
+	}
+	if v := anim.CubicBezier(0, 0, 1, 1)(0.3); v < 0.299 || v > 0.301 {
+		t.Errorf("linear CubicBezier(0.3) = %v, want 0.3", v)
+	}
+}
@@ -0,0 +1,35 @@
+package freyja
+
+import "sync"
+
+// cache keeps the values that were used recently.
+//
+// Values live in two generations: once the current one outgrows
+// the capacity, it replaces the previous one, and values that were not
+// used since are dropped. A cache is safe for concurrent use.
+type cache[K comparable, V any] struct {
+	capacity          int
+	mutex             sync.Mutex
+	current, previous map[K]V
+}
+
+// get returns the value of the key, making it with the function if it's not cached.
+func (c *cache[K, V]) get(key K, make func(key K) V) V {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if value, ok := c.current[key]; ok {
+		return value
+	}
+	value, ok := c.previous[key]
+	if !ok {
+		value = make(key)
+	}
+	if len(c.current) >= c.capacity {
+		c.previous, c.current = c.current, nil
+	}
+	if c.current == nil {
+		c.current = map[K]V{}
+	}
+	c.current[key] = value
+	return value
+}
@@ -0,0 +1,218 @@
+package freyja
+
+import (
+	"image"
+	"math"
+	"time"
+
+	"gioui.org/f32"
+	"gioui.org/io/semantic"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"github.com/widetape/freyja/pkg/freyja/anim"
+)
+
+// Checkbox is a box that is checked and unchecked by clicking it.
+//
+// Besides checked and unchecked, a checkbox can be indeterminate, which
+// is usually shown by parents of partially checked groups.
+type Checkbox struct {
+	Origin        widget.Bool // Origin is the bool of this checkbox.
+	Indeterminate bool        // Indeterminate shows a dash regardless of Origin.Value, clicking an indeterminate checkbox checks it.
+	Disabled      bool        // Disabled shows the checkbox disabled and makes it ignore input other than hovering.
+
+	Background         op.CallOp // Background is used to render the background of this checkbox.
+	BackgroundDisabled op.CallOp // BackgroundDisabled is used instead of Background in disabled mode.
+
+	Outline         op.CallOp // Outline is used to render the outline of this checkbox.
+	OutlineDisabled op.CallOp // OutlineDisabled is used instead of Outline in disabled mode.
+	OutlineWidth    unit.Dp   // OutlineWidth is the width of the outline.
+
+	Tint         op.CallOp // Tint is used to render the background of the checkbox when it's checked or indeterminate.
+	TintDisabled op.CallOp // TintDisabled is used instead of Tint in disabled mode.
+
+	Mark         op.CallOp // Mark is used to render the check mark and the dash.
+	MarkDisabled op.CallOp // MarkDisabled is used instead of Mark in disabled mode.
+	MarkWidth    unit.Dp   // MarkWidth is the width of the strokes of the check mark and the dash.
+
+	Size         unit.Dp // Size is the side of the box.
+	CornerRadius unit.Dp // CornerRadius is the radius of the corners of the box.
+
+	FocusRing FocusRing // FocusRing is drawn around the checkbox while it's focused.
+
+	Label ControlLabel // Label is the text next to the checkbox.
+
+	Duration time.Duration // Duration is how long the check mark takes to be drawn, erased or morphed from the dash.
+	Easing   anim.Easing   // Easing is the curve of the drawing, linear if nil.
+
+	progress anim.Tween[float32] // progress is the drawn part of the mark from 0 (none) to 1 (whole).
+	morph    anim.Tween[float32] // morph is the shape of the mark from 0 (dash) to 1 (check mark).
+
+	hoverable
+
+	themed
+}
+
+// checkMark and dash are the strokes of the marks in a box of side 1.
+// They have as many points, so one morphs into the other.
+var (
+	checkMark = []f32.Point{{X: 0.25, Y: 0.52}, {X: 0.42, Y: 0.69}, {X: 0.76, Y: 0.33}}
+	dash      = []f32.Point{{X: 0.28, Y: 0.5}, {X: 0.5, Y: 0.5}, {X: 0.72, Y: 0.5}}
+)
+
+// Layout lays the checkbox out to the context.
+func (c *Checkbox) Layout(gtx layout.Context) layout.Dimensions {
+	if c.stale() {
+		c.theme.paintCheckbox(c)
+	}
+	c.trackHover(gtx)
+	gtx, disabled := disable(gtx, c.Disabled)
+	value := c.Origin.Value
+	return c.Origin.Layout(
+		gtx,
+		func(gtx layout.Context) layout.Dimensions {
+			if c.Origin.Value != value && c.Indeterminate {
+				c.Origin.Value = true
+				c.Indeterminate = false
+			}
+			semantic.CheckBox.Add(gtx.Ops)
+			semantic.DisabledOp(disabled).Add(gtx.Ops)
+			c.addHover(gtx.Ops)
+			return c.Label.layout(
+				gtx,
+				disabled,
+				func(gtx layout.Context) layout.Dimensions {
+					if c.Indeterminate {
+						// Replaces the description added by the label.
+						description := "mixed"
+						if c.Label.Text != "" {
+							description = c.Label.Text + ", mixed"
+						}
+						semantic.DescriptionOp(description).Add(gtx.Ops)
+					}
+					var (
+						progress = c.advance(gtx)
+						side     = gtx.Dp(c.Size)
+						size     = image.Pt(side, side)
+						radius   = gtx.Dp(c.CornerRadius)
+						shape    = clip.UniformRRect(image.Rectangle{Max: size}, radius)
+					)
+					func() {
+						defer shape.Push(gtx.Ops).Pop()
+						switch {
+						case progress > 0 && disabled:
+							c.TintDisabled.Add(gtx.Ops)
+						case progress > 0:
+							c.Tint.Add(gtx.Ops)
+						case disabled:
+							c.BackgroundDisabled.Add(gtx.Ops)
+						default:
+							c.Background.Add(gtx.Ops)
+						}
+					}()
+					if progress == 0 {
+						var stroke = clip.Stroke{
+							Width: float32(gtx.Dp(c.OutlineWidth)),
+							Path:  shape.Path(gtx.Ops),
+						}
+						func() {
+							defer stroke.Op().Push(gtx.Ops).Pop()
+							if disabled {
+								c.OutlineDisabled.Add(gtx.Ops)
+							} else {
+								c.Outline.Add(gtx.Ops)
+							}
+						}()
+					}
+					mark := c.mark(gtx, progress)
+					if progress > 0 {
+						var stroke = clip.Stroke{
+							Width: float32(gtx.Dp(c.MarkWidth)),
+							Path:  polyline(gtx.Ops, mark, float32(side), progress),
+						}
+						func() {
+							defer stroke.Op().Push(gtx.Ops).Pop()
+							if disabled {
+								c.MarkDisabled.Add(gtx.Ops)
+							} else {
+								c.Mark.Add(gtx.Ops)
+							}
+						}()
+					}
+					if c.Origin.Focused() && !disabled {
+						c.FocusRing.draw(gtx, image.Rectangle{Max: size}, radius)
+					}
+					return layout.Dimensions{Size: size}
+				},
+			)
+		},
+	)
+}
+
+// advance draws or erases the mark towards the state of the checkbox and returns its progress.
+func (c *Checkbox) advance(gtx layout.Context) float32 {
+	var target float32
+	if c.Origin.Value || c.Indeterminate {
+		target = 1
+	}
+	c.progress.Duration = c.Duration
+	c.progress.Easing = c.Easing
+	return c.progress.Animate(gtx, target)
+}
+
+// mark morphs the mark towards the dash or the check mark and returns its points.
+func (c *Checkbox) mark(gtx layout.Context, progress float32) []f32.Point {
+	var target float32
+	if !c.Indeterminate {
+		target = 1
+	}
+	if progress == 0 {
+		// An erased mark is drawn again in its new shape.
+		c.morph.Set(target)
+	}
+	c.morph.Duration = c.Duration
+	c.morph.Easing = c.Easing
+	morph := c.morph.Animate(gtx, target)
+	points := make([]f32.Point, len(checkMark))
+	for i := range points {
+		points[i] = dash[i].Add(checkMark[i].Sub(dash[i]).Mul(morph))
+	}
+	return points
+}
+
+// polyline returns the path through the points scaled by the scale,
+// cut after the part of its length given by the progress.
+func polyline(ops *op.Ops, points []f32.Point, scale, progress float32) clip.PathSpec {
+	var total float32
+	for i := 1; i < len(points); i++ {
+		total += distance(points[i-1], points[i])
+	}
+	var (
+		path clip.Path
+		left = total * progress
+	)
+	path.Begin(ops)
+	path.MoveTo(points[0].Mul(scale))
+	for i := 1; i < len(points) && left > 0; i++ {
+		var (
+			from   = points[i-1]
+			to     = points[i]
+			length = distance(from, to)
+		)
+		if length > left {
+			to = from.Add(to.Sub(from).Mul(left / length))
+		}
+		path.LineTo(to.Mul(scale))
+		left -= length
+	}
+	return path.End()
+}
+
+// distance returns the distance between the points.
+func distance(a, b f32.Point) float32 {
+	d := b.Sub(a)
+	return float32(math.Hypot(float64(d.X), float64(d.Y)))
+}
@@ -0,0 +1,47 @@
+package freyja_test
+
+import (
+	"image"
+	"testing"
+
+	"gioui.org/f32"
+	"gioui.org/layout"
+)
+
+func TestCheckbox_Indeterminate(t *testing.T) {
+	theme := newTheme()
+	checkbox := theme.Checkbox()
+	checkbox.Indeterminate = true
+	h := newHarness(layout.Exact(image.Pt(100, 100)), checkbox.Layout)
+	h.frame()
+	h.click(f32.Pt(5, 5))
+	if checkbox.Indeterminate || !checkbox.Origin.Value {
+		t.Errorf("indeterminate checkbox is not checked after click: value %v, indeterminate %v", checkbox.Origin.Value, checkbox.Indeterminate)
+	}
+	h.click(f32.Pt(5, 5))
+	if checkbox.Origin.Value {
+		t.Error("checked checkbox is not unchecked after click")
+	}
+}
+
+func TestCheckbox_Morph(t *testing.T) {
+	theme := newTheme()
+	checkbox := theme.Checkbox()
+	checkbox.Indeterminate = true
+	h := newHarness(layout.Exact(image.Pt(100, 100)), checkbox.Layout)
+	h.frame()
+	if morph := checkbox.Morph(); morph != 0 {
+		t.Fatalf("indeterminate checkbox shows morph %v, want the dash", morph)
+	}
+	h.click(f32.Pt(5, 5))
+	h.gtx.Now = h.gtx.Now.Add(checkbox.Duration / 2)
+	h.frame()
+	if morph := checkbox.Morph(); morph <= 0 || morph >= 1 {
+		t.Errorf("got morph %v halfway to checked, want the dash turning into the check mark", morph)
+	}
+	h.gtx.Now = h.gtx.Now.Add(checkbox.Duration)
+	h.frame()
+	if morph := checkbox.Morph(); morph != 1 {
+		t.Errorf("got morph %v when checked, want the check mark", morph)
+	}
+}
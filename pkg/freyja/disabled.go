@@ -0,0 +1,92 @@
+package freyja
+
+import (
+	"image"
+
+	"gioui.org/gesture"
+	"gioui.org/io/event"
+	"gioui.org/io/pointer"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+)
+
+// Disable returns a copy of the context in which
+// every freyja widget is laid out disabled.
+//
+// Unlike layout.Context.Disabled, a disabled widget still tracks
+// hovering. It ignores presses, drags and keys, can't be focused
+// and is reported disabled to screen readers.
+func Disable(gtx layout.Context) layout.Context {
+	if _, ok := gtx.Queue.(hoverQueue); !ok {
+		gtx.Queue = hoverQueue{gtx.Queue}
+	}
+	return gtx
+}
+
+// disable reports whether a widget is disabled by its own flag or by the context,
+// and returns the context to lay out the widget with. The context of a disabled
+// widget has no queue, so the gio widgets it's built on neither take input nor
+// focus and report themselves disabled.
+func disable(gtx layout.Context, disabled bool) (layout.Context, bool) {
+	if _, ok := gtx.Queue.(hoverQueue); ok {
+		disabled = true
+	}
+	if disabled {
+		gtx.Queue = nil
+	}
+	return gtx, disabled
+}
+
+// hoverQueue passes only the pointer events that are needed to track hovering.
+type hoverQueue struct {
+	queue event.Queue
+}
+
+// Events returns the hovering events of the tag.
+func (q hoverQueue) Events(tag event.Tag) []event.Event {
+	if q.queue == nil {
+		return nil
+	}
+	var events []event.Event
+	for _, e := range q.queue.Events(tag) {
+		if e, ok := e.(pointer.Event); ok {
+			switch e.Type {
+			case pointer.Enter, pointer.Leave, pointer.Move, pointer.Cancel:
+				events = append(events, e)
+			}
+		}
+	}
+	return events
+}
+
+// hoverable tracks hovering of a widget apart from the gio widget it's built on,
+// which is laid out without a queue while the widget is disabled.
+type hoverable struct {
+	hover   gesture.Hover
+	hovered bool
+}
+
+// Hovered reports whether the pointer is over the widget, even if it's disabled.
+func (h *hoverable) Hovered() bool {
+	return h.hovered
+}
+
+// trackHover handles the hovering events. It must be called
+// with the context the widget is laid out with, before disable.
+func (h *hoverable) trackHover(gtx layout.Context) {
+	h.hovered = gtx.Queue != nil && h.hover.Hovered(gtx.Queue)
+}
+
+// addHover listens to hovering over the current clip area.
+func (h *hoverable) addHover(ops *op.Ops) {
+	h.hover.Add(ops)
+}
+
+// addHoverArea listens to hovering over the area of the size on top
+// of the widget, passing pointer events through to the widget.
+func (h *hoverable) addHoverArea(ops *op.Ops, size image.Point) {
+	defer pointer.PassOp{}.Push(ops).Pop()
+	defer clip.Rect{Max: size}.Push(ops).Pop()
+	h.hover.Add(ops)
+}
@@ -0,0 +1,54 @@
+package freyja_test
+
+import (
+	"image"
+	"testing"
+
+	"gioui.org/f32"
+	"gioui.org/io/pointer"
+	"gioui.org/layout"
+	"gioui.org/widget"
+	"github.com/widetape/freyja/pkg/freyja"
+)
+
+func TestDisabled_Hovered(t *testing.T) {
+	theme := newTheme()
+	var (
+		toggle      = theme.LabeledSwitch("Wi-Fi")
+		checkbox    = theme.LabeledCheckbox("Remember me")
+		radio       = theme.LabeledRadioButton(new(widget.Enum), "a", "A")
+		group       = theme.RadioGroup(freyja.RadioOption{Key: "a", Label: "A"})
+		slider      = theme.Slider()
+		rangeSlider = theme.RangeSlider()
+		field       = theme.TextField("Name")
+	)
+	toggle.Disabled = true
+	checkbox.Disabled = true
+	radio.Disabled = true
+	group.Buttons[0].Disabled = true
+	slider.Disabled = true
+	rangeSlider.Disabled = true
+	field.Disabled = true
+	widgets := []struct {
+		name    string
+		layout  layout.Widget
+		hovered func() bool
+	}{
+		{"Switch", toggle.Layout, toggle.Hovered},
+		{"Checkbox", checkbox.Layout, checkbox.Hovered},
+		{"RadioButton", radio.Layout, radio.Hovered},
+		{"RadioGroup option", group.Layout, group.Buttons[0].Hovered},
+		{"Slider", slider.Layout, slider.Hovered},
+		{"RangeSlider", rangeSlider.Layout, rangeSlider.Hovered},
+		{"TextField", field.Layout, field.Hovered},
+	}
+	for _, w := range widgets {
+		h := newHarness(layout.Constraints{Max: image.Pt(200, 100)}, w.layout)
+		h.frame()
+		h.queue.Queue(pointer.Event{Type: pointer.Move, Source: pointer.Mouse, Position: f32.Pt(12, 8)})
+		h.frame()
+		if !w.hovered() {
+			t.Errorf("disabled %s is not hovered", w.name)
+		}
+	}
+}
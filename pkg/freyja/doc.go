@@ -0,0 +1,16 @@
+// Package freyja provides widgets for Gio that are styled by a Theme
+// or field by field.
+//
+// # Shadows
+//
+// Widgets cast any number of shadows, so their shadow fields are Shadows:
+// PushButton.Shadow, SegmentedControl.Shadow, Switch.EnvironmentShadow,
+// Switch.KnobShadow, Slider.KnobShadow, SliderBubble.Shadow and TextField.Shadow.
+// Code that set a single Shadow sets Shadows{shadow} instead:
+//
+//	toggle.KnobShadow = freyja.Shadows{knobShadow}
+//
+// Outer shadows of rounded rectangles don't show through the shape they are
+// cast by. The environment shadow of a switch is cast into its track, so
+// Switch draws EnvironmentShadow inset even if Inset isn't set.
+package freyja
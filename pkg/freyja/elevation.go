@@ -0,0 +1,80 @@
+package freyja
+
+import (
+	"image/color"
+
+	"gioui.org/unit"
+)
+
+// Elevation is the height of a component above the surface it lies on,
+// from 0 for flat components to MaxElevation for the most raised ones.
+//
+// Fractional elevations cross-fade the shadows of the integer levels
+// around them, so elevations can be animated smoothly while the shapes
+// of the shadows, which are cached, stay the same.
+type Elevation float32
+
+// MaxElevation is the highest elevation, higher ones cast its shadows.
+const MaxElevation Elevation = 5
+
+// elevationShadows are the key and the ambient shadows of integer elevations.
+// Alphas of their colors are the opacities of the shadow color.
+var elevationShadows = [...][2]Shadow{
+	{},
+	{
+		{Color: color.NRGBA{A: 0x4D}, Spread: unit.Dp(2), Y: unit.Dp(1)},
+		{Color: color.NRGBA{A: 0x26}, Spread: unit.Dp(3), Y: unit.Dp(1)},
+	},
+	{
+		{Color: color.NRGBA{A: 0x4D}, Spread: unit.Dp(2), Y: unit.Dp(1)},
+		{Color: color.NRGBA{A: 0x26}, Spread: unit.Dp(6), Y: unit.Dp(2)},
+	},
+	{
+		{Color: color.NRGBA{A: 0x4D}, Spread: unit.Dp(3), Y: unit.Dp(1)},
+		{Color: color.NRGBA{A: 0x26}, Spread: unit.Dp(8), Y: unit.Dp(4)},
+	},
+	{
+		{Color: color.NRGBA{A: 0x4D}, Spread: unit.Dp(3), Y: unit.Dp(2)},
+		{Color: color.NRGBA{A: 0x26}, Spread: unit.Dp(10), Y: unit.Dp(6)},
+	},
+	{
+		{Color: color.NRGBA{A: 0x4D}, Spread: unit.Dp(4), Y: unit.Dp(4)},
+		{Color: color.NRGBA{A: 0x26}, Spread: unit.Dp(12), Y: unit.Dp(8)},
+	},
+}
+
+// Shadows returns the soft key and ambient shadows cast in the color at the elevation.
+// There are no shadows at elevation 0.
+func (e Elevation) Shadows(c color.NRGBA) Shadows {
+	if e <= 0 {
+		return nil
+	}
+	if e > MaxElevation {
+		e = MaxElevation
+	}
+	var (
+		level    = int(e)
+		progress = float32(e) - float32(level)
+		shadows  = make(Shadows, 0, 4)
+	)
+	shadows = appendElevationShadows(shadows, level, c, stepped(1-progress))
+	if level < int(MaxElevation) {
+		shadows = appendElevationShadows(shadows, level+1, c, stepped(progress))
+	}
+	return shadows
+}
+
+// appendElevationShadows appends the shadows of the integer elevation level
+// cast in the color and faded to the opacity. Nothing is appended for
+// shadows that are faded out.
+func appendElevationShadows(shadows Shadows, level int, c color.NRGBA, opacity float32) Shadows {
+	for _, shadow := range elevationShadows[level] {
+		if shadow.Color.A == 0 || opacity == 0 {
+			continue
+		}
+		shadow.Color = fade(c, float32(shadow.Color.A)/0xFF*opacity)
+		shadow.Soft = true
+		shadows = append(shadows, shadow)
+	}
+	return shadows
+}
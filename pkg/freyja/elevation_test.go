@@ -0,0 +1,42 @@
+package freyja_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/widetape/freyja/pkg/freyja"
+)
+
+func TestElevation_Shadows(t *testing.T) {
+	black := color.NRGBA{A: 0xFF}
+	if shadows := freyja.Elevation(0).Shadows(black); shadows != nil {
+		t.Errorf("got shadows %v at elevation 0", shadows)
+	}
+	var (
+		low    = freyja.Elevation(2).Shadows(black)
+		middle = freyja.Elevation(2.5).Shadows(black)
+		high   = freyja.Elevation(3).Shadows(black)
+	)
+	// Elevations between levels cross-fade the shadows of both levels.
+	if len(middle) != len(low)+len(high) {
+		t.Fatalf("got %d shadows at elevation 2.5, want %d", len(middle), len(low)+len(high))
+	}
+	for i, shadow := range middle {
+		level := low[i%len(low)]
+		if i >= len(low) {
+			level = high[i-len(low)]
+		}
+		if shadow.Spread != level.Spread || shadow.Y != level.Y {
+			t.Errorf("shadow %d at elevation 2.5 %+v has not the shape of %+v", i, shadow, level)
+		}
+		if shadow.Color.A == 0 || shadow.Color.A >= level.Color.A {
+			t.Errorf("shadow %d at elevation 2.5 has opacity %d, want it faded from %d", i, shadow.Color.A, level.Color.A)
+		}
+	}
+	max := freyja.MaxElevation.Shadows(black)
+	for i, shadow := range freyja.Elevation(9).Shadows(black) {
+		if shadow != max[i] {
+			t.Errorf("shadow %d above the maximum elevation is %+v, want %+v", i, shadow, max[i])
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package freyja
+
+import (
+	"image"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/unit"
+)
+
+// TintSpan exposes tintSpan to tests.
+func (s *Slider) TintSpan(value float32, length int) (from, to int) {
+	return s.tintSpan(value, length)
+}
+
+// Morph returns the shape of the mark from 0 (dash) to 1 (check mark).
+func (c *Checkbox) Morph() float32 {
+	return c.morph.Value()
+}
+
+// StrokedShadow returns the recording of the stroked shadow of the rounded rectangle from the cache.
+func StrokedShadow(s Shadow, shape clip.RRect, metric unit.Metric) op.CallOp {
+	return strokedShadows.get(strokedShadowKey{shadow: s, shape: shape, metric: metric}, strokedShadow)
+}
+
+// CurrentElevation returns the elevation the push button is animated at.
+func (b *PushButton) CurrentElevation() Elevation {
+	return Elevation(b.elevation.Value())
+}
+
+// SoftShadowMask returns the coverage of the soft shadow of the rounded rectangle
+// at one pixel per dp and the offset of the rectangle in it.
+func SoftShadowMask(s Shadow, shape clip.RRect) (*image.Alpha, image.Point) {
+	gtx := layout.Context{Metric: unit.Metric{PxPerDp: 1, PxPerSp: 1}}
+	key := s.softKey(gtx, shape)
+	return softShadowMasks.get(key, softShadowMask), key.hole
+}
+
+// EnvironmentShadows returns the environment shadows the switch draws.
+func (s *Switch) EnvironmentShadows() Shadows {
+	return s.environmentShadow()
+}
@@ -0,0 +1,36 @@
+package freyja
+
+import (
+	"image"
+	"image/color"
+
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+)
+
+// FocusRing is the outline drawn around a widget while it holds keyboard focus.
+type FocusRing struct {
+	Color  color.NRGBA // Color is the color of the ring, there is no ring if it's transparent.
+	Width  unit.Dp     // Width is the width of the ring.
+	Offset unit.Dp     // Offset is the gap between the widget and the ring.
+}
+
+// draw draws the ring around the rounded rectangle,
+// keeping its corners concentric with the rectangle's ones.
+func (r *FocusRing) draw(gtx layout.Context, bounds image.Rectangle, radius int) {
+	if r.Color.A == 0 || r.Width <= 0 {
+		return
+	}
+	var (
+		width  = gtx.Dp(r.Width)
+		offset = gtx.Dp(r.Offset) + width/2
+		shape  = clip.UniformRRect(bounds.Inset(-offset), radius+offset)
+		stroke = clip.Stroke{
+			Path:  shape.Path(gtx.Ops),
+			Width: float32(width),
+		}
+	)
+	paint.FillShape(gtx.Ops, r.Color, stroke.Op())
+}
@@ -0,0 +1,68 @@
+package freyja_test
+
+import (
+	"time"
+
+	"gioui.org/f32"
+	"gioui.org/font/gofont"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/io/router"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/text"
+	"gioui.org/unit"
+	"github.com/widetape/freyja/pkg/freyja"
+)
+
+// newTheme returns a theme with the Go fonts.
+func newTheme() *freyja.Theme {
+	fonts := gofont.Collection()
+	return freyja.NewTheme(text.NewShaper(fonts), fonts[0].Font)
+}
+
+// harness lays a widget out frame by frame and routes events to it, like a window does.
+type harness struct {
+	queue  *router.Router
+	gtx    layout.Context
+	widget layout.Widget
+}
+
+// newHarness returns a harness that lays the widget out with the constraints at one pixel per dp.
+func newHarness(constraints layout.Constraints, widget layout.Widget) *harness {
+	queue := new(router.Router)
+	return &harness{
+		queue: queue,
+		gtx: layout.Context{
+			Constraints: constraints,
+			Metric:      unit.Metric{PxPerDp: 1, PxPerSp: 1},
+			Queue:       queue,
+			Now:         time.Now(),
+			Ops:         new(op.Ops),
+		},
+		widget: widget,
+	}
+}
+
+// frame lays the widget out and routes the events of the next frame to it.
+func (h *harness) frame() layout.Dimensions {
+	h.gtx.Ops.Reset()
+	dimensions := h.widget(h.gtx)
+	h.queue.Frame(h.gtx.Ops)
+	return dimensions
+}
+
+// click clicks the primary button at the position and lays the widget out.
+func (h *harness) click(position f32.Point) {
+	h.queue.Queue(
+		pointer.Event{Type: pointer.Press, Source: pointer.Mouse, Buttons: pointer.ButtonPrimary, Position: position},
+		pointer.Event{Type: pointer.Release, Source: pointer.Mouse, Position: position},
+	)
+	h.frame()
+}
+
+// press presses the key and lays the widget out.
+func (h *harness) press(name string) {
+	h.queue.Queue(key.Event{Name: name, State: key.Press})
+	h.frame()
+}
@@ -0,0 +1,40 @@
+package freyja
+
+import (
+	"image/color"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/op/paint"
+	"github.com/widetape/freyja/pkg/freyja/anim"
+)
+
+// highlight fades hover and click colors in and out over a widget.
+type highlight struct {
+	hover anim.Tween[float32] // hover is the opacity of the hover color.
+	click anim.Tween[float32] // click is the opacity of the click color.
+}
+
+// draw fills the current clip with the click color while the widget is pressed
+// and with the hover color while it's only hovered, fading them over the duration.
+func (h *highlight) draw(gtx layout.Context, hovered, pressed bool, hoverColor, clickColor color.NRGBA, duration time.Duration) {
+	var hover, click float32
+	if pressed {
+		click = 1
+	} else if hovered {
+		hover = 1
+	}
+	h.hover.Duration = duration
+	h.click.Duration = duration
+	if color := fade(hoverColor, h.hover.Animate(gtx, hover)); color.A > 0 {
+		paint.Fill(gtx.Ops, color)
+	}
+	if color := fade(clickColor, h.click.Animate(gtx, click)); color.A > 0 {
+		paint.Fill(gtx.Ops, color)
+	}
+}
+
+// reset drops the colors right away, so they fade in from scratch.
+func (h *highlight) reset() {
+	*h = highlight{}
+}
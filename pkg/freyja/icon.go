@@ -0,0 +1,20 @@
+package freyja
+
+import (
+	"image/color"
+
+	"gioui.org/layout"
+)
+
+// Icon draws an icon of the size of the minimum constraints in the color.
+//
+// The Layout method of widget.Icon is an Icon.
+type Icon func(gtx layout.Context, color color.NRGBA) layout.Dimensions
+
+// WidgetIcon returns an icon that lays out the widget,
+// which is drawn in its own colors instead of the color of the icon.
+func WidgetIcon(w layout.Widget) Icon {
+	return func(gtx layout.Context, _ color.NRGBA) layout.Dimensions {
+		return w(gtx)
+	}
+}
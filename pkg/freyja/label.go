@@ -0,0 +1,70 @@
+package freyja
+
+import (
+	"gioui.org/font"
+	"gioui.org/io/semantic"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/text"
+	"gioui.org/unit"
+	"gioui.org/widget"
+)
+
+// LabelSide is the side of a control its label is placed on.
+type LabelSide uint8
+
+const (
+	LabelAfter  LabelSide = iota // LabelAfter places the label to the right of the control.
+	LabelBefore                  // LabelBefore places the label to the left of the control.
+)
+
+// ControlLabel is the text next to a switch, a radio button or a checkbox.
+//
+// The label is a part of the control: clicking it toggles the control
+// and screen readers use it as the description of the control.
+// There is no label if Text is empty.
+type ControlLabel struct {
+	Text string    // Text is the text of the label.
+	Side LabelSide // Side is the side of the control the label is placed on.
+
+	Shaper   *text.Shaper // Shaper is used to layout the text.
+	Font     font.Font    // Font is used for the text.
+	FontSize unit.Sp      // FontSize is the size of the text.
+
+	Foreground         op.CallOp // Foreground is the material operation for the text.
+	ForegroundDisabled op.CallOp // ForegroundDisabled is used instead of Foreground in disabled mode.
+
+	Spacing unit.Dp // Spacing is the gap between the control and the label.
+}
+
+// layout lays the control out with the label next to it.
+func (l *ControlLabel) layout(gtx layout.Context, disabled bool, control layout.Widget) layout.Dimensions {
+	if l.Text == "" || l.Shaper == nil {
+		return control(gtx)
+	}
+	semantic.DescriptionOp(l.Text).Add(gtx.Ops)
+	var (
+		label = layout.Rigid(
+			func(gtx layout.Context) layout.Dimensions {
+				color := l.Foreground
+				if disabled {
+					color = l.ForegroundDisabled
+				}
+				return widget.Label{MaxLines: 1}.Layout(
+					gtx,
+					l.Shaper,
+					l.Font,
+					l.FontSize,
+					l.Text,
+					color,
+				)
+			},
+		)
+		spacer = layout.Rigid(layout.Spacer{Width: l.Spacing}.Layout)
+		flex   = layout.Flex{Alignment: layout.Middle}
+	)
+	if l.Side == LabelBefore {
+		return flex.Layout(gtx, label, spacer, layout.Rigid(control))
+	}
+	return flex.Layout(gtx, layout.Rigid(control), spacer, label)
+}
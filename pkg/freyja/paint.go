@@ -0,0 +1,46 @@
+package freyja
+
+import (
+	"image/color"
+	"sync"
+
+	"gioui.org/op"
+	"gioui.org/op/paint"
+)
+
+// fills keeps the operations recorded by fill.
+//
+// They are never dropped: a theme resolves a handful of colors, and equal
+// colors must keep resolving to equal operations, so a widget can tell
+// the colors it was painted with from the ones set by hand.
+var fills = struct {
+	mutex sync.Mutex
+	ops   map[color.NRGBA]op.CallOp
+}{ops: map[color.NRGBA]op.CallOp{}}
+
+// fill returns an operation that fills the current clip with the color.
+// The operation of a transparent color is empty.
+func fill(c color.NRGBA) op.CallOp {
+	if c.A == 0 {
+		return op.CallOp{}
+	}
+	fills.mutex.Lock()
+	defer fills.mutex.Unlock()
+	if call, ok := fills.ops[c]; ok {
+		return call
+	}
+	var (
+		ops    = new(op.Ops)
+		record = op.Record(ops)
+	)
+	paint.Fill(ops, c)
+	call := record.Stop()
+	fills.ops[c] = call
+	return call
+}
+
+// fade returns the color with its alpha scaled by the opacity.
+func fade(c color.NRGBA, opacity float32) color.NRGBA {
+	c.A = uint8(float32(c.A) * opacity)
+	return c
+}
@@ -3,6 +3,8 @@ package freyja
 import (
 	"image"
 	"image/color"
+	"math"
+	"time"
 
 	"gioui.org/font"
 	"gioui.org/io/semantic"
@@ -13,17 +15,44 @@ import (
 	"gioui.org/text"
 	"gioui.org/unit"
 	"gioui.org/widget"
+	"github.com/widetape/freyja/pkg/freyja/anim"
 )
 
-// PushButton is a button with text.
+// Emphasis is the visual weight of a push button.
+type Emphasis uint8
+
+const (
+	Filled   Emphasis = iota // Filled buttons are filled with the primary color for the main actions.
+	Tonal                    // Tonal buttons are filled with a muted color for secondary actions.
+	Outlined                 // Outlined buttons have an outline and no background for alternative actions.
+	TextOnly                 // TextOnly buttons have neither outline nor background for the least important actions.
+)
+
+// PushButton is a button with text and icons.
+//
+// A push button with icons and without Label is a circle around its icons.
 type PushButton struct {
-	Origin widget.Clickable // Origin is the clickable of this push button.
+	Origin   widget.Clickable // Origin is the clickable of this push button.
+	Disabled bool             // Disabled shows the push button disabled and makes it ignore input other than hovering.
 
 	Background         op.CallOp // Background is called to fill the background of this button.
 	BackgroundDisabled op.CallOp // BackgroundDisabled is used instead of Background in disabled mode.
 	CornerRadius       unit.Dp   // CornerRadius is the radius of smooth corners.
 
-	Shadow Shadow // Shadow is the shadow casted by this button.
+	Outline         op.CallOp // Outline is used to render the outline of this button, there is no outline if it's empty.
+	OutlineDisabled op.CallOp // OutlineDisabled is used instead of Outline in disabled mode.
+	OutlineWidth    unit.Dp   // OutlineWidth is the width of the outline, which is drawn inside the button.
+
+	Emphasis Emphasis // Emphasis selects the colors of this button when it's built or repainted by a theme.
+
+	Shadow Shadows // Shadow is the shadow casted by this button besides the shadows of its elevation.
+
+	Elevation      Elevation   // Elevation is the elevation of this button at rest.
+	HoverElevation Elevation   // HoverElevation is the elevation of this button while it's hovered.
+	PressElevation Elevation   // PressElevation is the elevation of this button while it's being pressed.
+	ShadowColor    color.NRGBA // ShadowColor is the color of the shadows of the elevation.
+
+	FocusRing FocusRing // FocusRing is drawn around the push button while it's focused.
 
 	Inset layout.Inset // Inset is used to margin the text from corners of this button.
 
@@ -35,13 +64,50 @@ type PushButton struct {
 	Foreground         op.CallOp // Foreground is the material operation for the text.
 	ForegroundDisabled op.CallOp // ForegroundDisabled is used instead of Foreground in disabled mode.
 
+	LeadingIcon       Icon        // LeadingIcon is drawn before the text, there is no icon if it's nil.
+	TrailingIcon      Icon        // TrailingIcon is drawn after the text, there is no icon if it's nil.
+	IconSize          unit.Dp     // IconSize is the size of icons.
+	IconSpacing       unit.Dp     // IconSpacing is the gap between icons and the text.
+	IconColor         color.NRGBA // IconColor is the color of icons, it should match Foreground. Zero picks the color of the text.
+	IconColorDisabled color.NRGBA // IconColorDisabled is used instead of IconColor in disabled mode, it should match ForegroundDisabled.
+
 	HoverColor color.NRGBA // HoverColor is drawn over the push button when it's hovered.
-	ClickColor color.NRGBA // ClickColor is drawn over the push button while it's being pressed.
+	ClickColor color.NRGBA // ClickColor is drawn over the push button while it's being pressed, unless the press ripples.
+
+	FadeDuration time.Duration // FadeDuration is how long HoverColor and ClickColor take to fade in and out.
+
+	RippleColor    color.NRGBA   // RippleColor is the ripple spreading from the press point, there is no ripple if it's transparent.
+	RippleDuration time.Duration // RippleDuration is how long the ripple spreads and fades out, it should be under a second.
+
+	Loading bool    // Loading shows Spinner instead of LeadingIcon, or instead of the whole content without one, and makes the push button inert like a disabled one.
+	Spinner Spinner // Spinner is drawn in the color and the size of icons while the push button is loading.
+
+	highlight highlight           // highlight fades HoverColor and ClickColor.
+	elevation anim.Tween[float32] // elevation moves between Elevation, HoverElevation and PressElevation.
+
+	hoverable
+
+	emphasis Emphasis // emphasis is the Emphasis the colors were resolved for.
+
+	themed
 }
 
 // Layout lays PushButton out to the context.
 func (b *PushButton) Layout(gtx layout.Context) layout.Dimensions {
-	var disabled = gtx.Queue == nil
+	if b.stale() || b.theme != nil && b.emphasis != b.Emphasis {
+		b.theme.paintPushButton(b)
+	}
+	b.trackHover(gtx)
+	gtx, disabled := disable(gtx, b.Disabled)
+	// Unlike disabled push buttons, loading ones look as usual, but they are just as inert.
+	inert := disabled || b.Loading
+	if inert {
+		gtx.Queue = nil
+	}
+	circle := b.Label == "" && (b.LeadingIcon != nil || b.TrailingIcon != nil)
+	if circle {
+		gtx.Constraints.Min = b.square(gtx, disabled)
+	}
 	contentRecord := op.Record(gtx.Ops)
 	dimensions := layout.Center.Layout(
 		gtx,
@@ -49,20 +115,7 @@ func (b *PushButton) Layout(gtx layout.Context) layout.Dimensions {
 			return b.Inset.Layout(
 				gtx,
 				func(gtx layout.Context) layout.Dimensions {
-					var color op.CallOp
-					if disabled {
-						color = b.ForegroundDisabled
-					} else {
-						color = b.Foreground
-					}
-					return widget.Label{MaxLines: 1}.Layout(
-						gtx,
-						b.Shaper,
-						b.Font,
-						b.FontSize,
-						b.Label,
-						color,
-					)
+					return b.content(gtx, disabled)
 				},
 			)
 		},
@@ -70,38 +123,250 @@ func (b *PushButton) Layout(gtx layout.Context) layout.Dimensions {
 	content := contentRecord.Stop()
 	var (
 		size   = dimensions.Size
-		shape  = clip.UniformRRect(image.Rectangle{Max: size}, gtx.Dp(b.CornerRadius))
+		radius = gtx.Dp(b.CornerRadius)
 		shadow = b.Shadow
 	)
+	if circle {
+		radius = size.Y / 2
+	}
+	shape := clip.UniformRRect(image.Rectangle{Max: size}, radius)
 	if disabled {
-		shadow.Color.A = 0
+		shadow = nil
+		b.elevation = anim.Tween[float32]{}
+	} else if elevation := b.elevate(gtx, inert); elevation > 0 {
+		shadow = append(shadow[:len(shadow):len(shadow)], elevation.Shadows(b.ShadowColor)...)
 	}
-	shadow.Layout(
+	shadow.LayoutRRect(
 		gtx,
-		shape.Path(gtx.Ops),
+		shape,
 		func(gtx layout.Context) layout.Dimensions {
-			semantic.Button.Add(gtx.Ops)
 			return b.Origin.Layout(
 				gtx,
 				func(gtx layout.Context) layout.Dimensions {
+					semantic.Button.Add(gtx.Ops)
+					semantic.DisabledOp(inert).Add(gtx.Ops)
+					b.addHover(gtx.Ops)
 					defer shape.Push(gtx.Ops).Pop()
-					if disabled {
+					switch {
+					case disabled:
 						b.BackgroundDisabled.Add(gtx.Ops)
-					} else {
+						b.highlight.reset()
+					case inert:
 						b.Background.Add(gtx.Ops)
-						if b.Origin.Pressed() {
-							paint.Fill(gtx.Ops, b.ClickColor)
-						} else {
-							if b.Origin.Hovered() {
-								paint.Fill(gtx.Ops, b.HoverColor)
+						b.highlight.reset()
+					default:
+						b.Background.Add(gtx.Ops)
+						// The ripple marks the press on its own, so the press
+						// doesn't darken the push button twice.
+						clickColor := b.ClickColor
+						ripples := b.RippleColor.A > 0 && b.RippleDuration > 0
+						if ripples {
+							clickColor = color.NRGBA{}
+						}
+						b.highlight.draw(
+							gtx,
+							b.Hovered(),
+							b.Origin.Pressed(),
+							b.HoverColor,
+							clickColor,
+							b.FadeDuration,
+						)
+						if ripples {
+							for _, press := range b.Origin.History() {
+								b.ripple(gtx, press, size)
 							}
 						}
 					}
+					b.outline(gtx, size, radius, disabled)
 					return layout.Dimensions{Size: size}
 				},
 			)
 		},
 	)
-	content.Add(gtx.Ops)
+	if b.Origin.Focused() && !inert {
+		b.FocusRing.draw(gtx, image.Rectangle{Max: size}, radius)
+	}
+	if b.Loading && b.LeadingIcon == nil {
+		// The content is measured anyway, so the push button keeps its size.
+		b.spin(gtx, size, disabled)
+	} else {
+		content.Add(gtx.Ops)
+	}
 	return dimensions
 }
+
+// spin draws the spinner of the size of icons in the center of the push button.
+func (b *PushButton) spin(gtx layout.Context, size image.Point, disabled bool) {
+	color := b.iconColor(disabled)
+	side := gtx.Dp(b.IconSize)
+	defer op.Offset(size.Sub(image.Pt(side, side)).Div(2)).Push(gtx.Ops).Pop()
+	gtx.Constraints = layout.Exact(image.Pt(side, side))
+	b.Spinner.Layout(gtx, color)
+}
+
+// elevate moves the elevation towards the one of the state of the push button and returns it.
+func (b *PushButton) elevate(gtx layout.Context, inert bool) Elevation {
+	target := b.Elevation
+	switch {
+	case inert:
+		// An inert push button rests at its Elevation.
+	case b.Origin.Pressed():
+		target = b.PressElevation
+	case b.Hovered():
+		target = b.HoverElevation
+	}
+	b.elevation.Duration = b.FadeDuration
+	return Elevation(b.elevation.Animate(gtx, float32(target)))
+}
+
+// iconColor returns the color of icons. If it's zero, icons take the color of the text:
+// the one of the emphasis in the palette of the theme, or black without a theme.
+func (b *PushButton) iconColor(disabled bool) color.NRGBA {
+	c := b.IconColor
+	if disabled {
+		c = b.IconColorDisabled
+	}
+	switch {
+	case c != (color.NRGBA{}):
+		return c
+	case b.theme == nil:
+		return color.NRGBA{A: 0xFF}
+	case disabled:
+		return b.theme.Palette().OnDisabled
+	}
+	_, foreground, _ := emphasisColors(b.theme.Palette(), b.Emphasis)
+	return foreground
+}
+
+// outline draws the outline inside the bounds of the push button.
+func (b *PushButton) outline(gtx layout.Context, size image.Point, radius int, disabled bool) {
+	outline := b.Outline
+	if disabled {
+		outline = b.OutlineDisabled
+	}
+	width := gtx.Dp(b.OutlineWidth)
+	if width <= 0 || outline == (op.CallOp{}) {
+		return
+	}
+	var (
+		shape  = clip.UniformRRect(image.Rectangle{Max: size}.Inset(width/2), radius-width/2)
+		stroke = clip.Stroke{
+			Path:  shape.Path(gtx.Ops),
+			Width: float32(width),
+		}
+	)
+	defer stroke.Op().Push(gtx.Ops).Pop()
+	outline.Add(gtx.Ops)
+}
+
+// content lays out the icons and the text of the push button.
+func (b *PushButton) content(gtx layout.Context, disabled bool) layout.Dimensions {
+	var (
+		foreground = b.Foreground
+		iconColor  = b.iconColor(disabled)
+		items      []layout.FlexChild
+	)
+	if disabled {
+		foreground = b.ForegroundDisabled
+	}
+	icon := func(icon Icon) layout.FlexChild {
+		return layout.Rigid(
+			func(gtx layout.Context) layout.Dimensions {
+				size := gtx.Dp(b.IconSize)
+				gtx.Constraints = layout.Exact(image.Pt(size, size))
+				return icon(gtx, iconColor)
+			},
+		)
+	}
+	if b.LeadingIcon != nil {
+		leading := b.LeadingIcon
+		if b.Loading {
+			leading = b.Spinner.Layout
+		}
+		items = append(items, icon(leading))
+	}
+	if b.Label != "" || b.LeadingIcon == nil && b.TrailingIcon == nil {
+		items = append(
+			items,
+			layout.Rigid(
+				func(gtx layout.Context) layout.Dimensions {
+					return widget.Label{MaxLines: 1}.Layout(
+						gtx,
+						b.Shaper,
+						b.Font,
+						b.FontSize,
+						b.Label,
+						foreground,
+					)
+				},
+			),
+		)
+	}
+	if b.TrailingIcon != nil {
+		items = append(items, icon(b.TrailingIcon))
+	}
+	children := make([]layout.FlexChild, 0, 2*len(items))
+	for i, item := range items {
+		if i > 0 {
+			children = append(children, layout.Rigid(layout.Spacer{Width: b.IconSpacing}.Layout))
+		}
+		children = append(children, item)
+	}
+	return layout.Flex{Alignment: layout.Middle}.Layout(gtx, children...)
+}
+
+// square returns the minimum size of a circular push button:
+// the square around its content and the minimum constraints.
+func (b *PushButton) square(gtx layout.Context, disabled bool) image.Point {
+	mgtx := gtx
+	mgtx.Constraints.Min = image.Point{}
+	record := op.Record(gtx.Ops)
+	dimensions := b.Inset.Layout(
+		mgtx,
+		func(gtx layout.Context) layout.Dimensions {
+			return b.content(gtx, disabled)
+		},
+	)
+	record.Stop()
+	side := dimensions.Size.X
+	for _, length := range []int{dimensions.Size.Y, gtx.Constraints.Min.X, gtx.Constraints.Min.Y} {
+		if length > side {
+			side = length
+		}
+	}
+	return image.Pt(side, side)
+}
+
+// ripple draws the ripple of the press, which spreads from the press
+// point until it covers the whole button and fades out after release.
+func (b *PushButton) ripple(gtx layout.Context, press widget.Press, size image.Point) {
+	var (
+		spread  = float32(gtx.Now.Sub(press.Start)) / float32(b.RippleDuration)
+		opacity = float32(1)
+	)
+	if !press.End.IsZero() {
+		opacity -= float32(gtx.Now.Sub(press.End)) / float32(b.RippleDuration)
+		if opacity <= 0 {
+			return
+		}
+	}
+	if press.Cancelled {
+		spread = float32(press.End.Sub(press.Start)) / float32(b.RippleDuration)
+	}
+	if spread > 1 {
+		spread = 1
+	}
+	if spread < 1 || !press.End.IsZero() {
+		op.InvalidateOp{}.Add(gtx.Ops)
+	}
+	var (
+		dx     = math.Max(float64(press.Position.X), float64(size.X-press.Position.X))
+		dy     = math.Max(float64(press.Position.Y), float64(size.Y-press.Position.Y))
+		radius = int(spread * float32(math.Hypot(dx, dy)))
+		shape  = clip.Ellipse{
+			Min: press.Position.Sub(image.Pt(radius, radius)),
+			Max: press.Position.Add(image.Pt(radius, radius)),
+		}
+	)
+	paint.FillShape(gtx.Ops, fade(b.RippleColor, opacity), shape.Op(gtx.Ops))
+}
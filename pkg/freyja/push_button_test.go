@@ -6,7 +6,11 @@ import (
 	"testing"
 	"time"
 
+	"gioui.org/f32"
 	"gioui.org/font/gofont"
+	"gioui.org/io/pointer"
+	"gioui.org/io/router"
+	"gioui.org/io/semantic"
 	"gioui.org/io/system"
 	"gioui.org/layout"
 	"gioui.org/op"
@@ -118,3 +122,153 @@ func BenchmarkPushButton_Layout(b *testing.B) {
 		b.StopTimer()
 	}
 }
+
+func TestPushButton_Disabled(t *testing.T) {
+	theme := newTheme()
+	button := theme.PushButton("Label")
+	button.Disabled = true
+	h := newHarness(layout.Exact(image.Pt(100, 100)), button.Layout)
+	h.frame()
+	h.queue.Queue(pointer.Event{Type: pointer.Move, Source: pointer.Mouse, Position: f32.Pt(10, 10)})
+	h.click(f32.Pt(10, 10))
+	if button.Origin.Clicked() {
+		t.Error("disabled push button was clicked")
+	}
+	if !button.Hovered() {
+		t.Error("disabled push button is not hovered")
+	}
+}
+
+func TestPushButton_HoverElevation(t *testing.T) {
+	theme := newTheme()
+	button := theme.PushButton("Label")
+	h := newHarness(layout.Exact(image.Pt(100, 100)), button.Layout)
+	h.frame()
+	if elevation := button.CurrentElevation(); elevation != button.Elevation {
+		t.Fatalf("push button at rest is at elevation %v, want %v", elevation, button.Elevation)
+	}
+	h.queue.Queue(pointer.Event{Type: pointer.Move, Source: pointer.Mouse, Position: f32.Pt(10, 10)})
+	h.frame()
+	h.gtx.Now = h.gtx.Now.Add(button.FadeDuration / 2)
+	h.frame()
+	if elevation := button.CurrentElevation(); elevation <= button.Elevation || elevation >= button.HoverElevation {
+		t.Errorf("hovered push button is at elevation %v halfway, want it between %v and %v", elevation, button.Elevation, button.HoverElevation)
+	}
+	h.gtx.Now = h.gtx.Now.Add(button.FadeDuration)
+	h.frame()
+	if elevation := button.CurrentElevation(); elevation != button.HoverElevation {
+		t.Errorf("hovered push button is at elevation %v, want %v", elevation, button.HoverElevation)
+	}
+}
+
+func TestPushButton_DisabledFocus(t *testing.T) {
+	theme := newTheme()
+	var (
+		disabled = theme.PushButton("Disabled")
+		enabled  = theme.PushButton("Enabled")
+	)
+	disabled.Disabled = true
+	h := newHarness(
+		layout.Constraints{Max: image.Pt(400, 100)},
+		func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{}.Layout(
+				gtx,
+				layout.Rigid(disabled.Layout),
+				layout.Rigid(enabled.Layout),
+			)
+		},
+	)
+	h.frame()
+	h.queue.MoveFocus(router.FocusForward)
+	h.frame()
+	if disabled.Origin.Focused() || !enabled.Origin.Focused() {
+		t.Error("focus did not skip the disabled push button")
+	}
+	var disabledButtons int
+	for _, node := range h.queue.AppendSemantics(nil) {
+		if node.Desc.Class == semantic.Button && node.Desc.Disabled {
+			disabledButtons++
+		}
+	}
+	if disabledButtons != 1 {
+		t.Errorf("got %d disabled push buttons for screen readers, want 1", disabledButtons)
+	}
+}
+
+func TestPushButton_IconOnly(t *testing.T) {
+	theme := newTheme()
+	button := theme.PushButton("")
+	button.LeadingIcon = freyja.WidgetIcon(
+		func(gtx layout.Context) layout.Dimensions {
+			return layout.Dimensions{Size: gtx.Constraints.Min}
+		},
+	)
+	gtx := layout.Context{
+		Constraints: layout.Constraints{Max: image.Pt(200, 200)},
+		Metric:      unit.Metric{PxPerDp: 1, PxPerSp: 1},
+		Ops:         new(op.Ops),
+	}
+	size := button.Layout(gtx).Size
+	if size.X != size.Y || size.X < 18 {
+		t.Errorf("icon-only push button is %v, want a square around the icon", size)
+	}
+}
+
+func TestPushButton_IconColor(t *testing.T) {
+	var got color.NRGBA
+	button := freyja.PushButton{
+		LeadingIcon: func(gtx layout.Context, color color.NRGBA) layout.Dimensions {
+			got = color
+			return layout.Dimensions{Size: gtx.Constraints.Min}
+		},
+		IconSize: 18,
+	}
+	button.Layout(layout.Context{
+		Constraints: layout.Constraints{Max: image.Pt(100, 100)},
+		Ops:         new(op.Ops),
+	})
+	if want := (color.NRGBA{A: 0xFF}); got != want {
+		t.Errorf("got icon color %v on a push button without IconColor, want %v like its text", got, want)
+	}
+}
+
+func TestPushButton_Emphasis(t *testing.T) {
+	theme := newTheme()
+	button := theme.OutlinedPushButton("Label")
+	if button.IconColor != freyja.LightPalette.Primary {
+		t.Fatalf("got outlined icon color %v, want %v", button.IconColor, freyja.LightPalette.Primary)
+	}
+	button.Emphasis = freyja.Tonal
+	button.Layout(layout.Context{
+		Constraints: layout.Exact(image.Pt(100, 100)),
+		Ops:         new(op.Ops),
+	})
+	if button.IconColor != freyja.LightPalette.OnTonal {
+		t.Fatalf("got tonal icon color %v, want %v", button.IconColor, freyja.LightPalette.OnTonal)
+	}
+}
+
+func TestPushButton_Loading(t *testing.T) {
+	theme := newTheme()
+	button := theme.PushButton("Upload")
+	h := newHarness(layout.Constraints{Max: image.Pt(200, 100)}, button.Layout)
+	size := h.frame().Size
+	button.Loading = true
+	if loading := h.frame().Size; loading != size {
+		t.Errorf("loading push button is %v, want %v", loading, size)
+	}
+	h.click(f32.Pt(10, 10))
+	if button.Origin.Clicked() {
+		t.Error("loading push button was clicked")
+	}
+	h.queue.MoveFocus(router.FocusForward)
+	h.frame()
+	if button.Origin.Focused() {
+		t.Error("loading push button took the focus")
+	}
+	for _, node := range h.queue.AppendSemantics(nil) {
+		if node.Desc.Class == semantic.Button && !node.Desc.Disabled {
+			t.Error("loading push button is not reported disabled to screen readers")
+		}
+	}
+}
@@ -17,6 +17,8 @@ type RadioButton struct {
 	Group *widget.Enum // Group is the group this radio button belongs to.
 	Key   string       // Key is this radio button's key in the group.
 
+	Disabled bool // Disabled shows the radio button disabled and makes it ignore input other than hovering.
+
 	Background         op.CallOp // Background is used to render the background of this radio button.
 	BackgroundDisabled op.CallOp // BackgroundDisabled is used instead of Background in disabled mode.
 
@@ -31,80 +33,106 @@ type RadioButton struct {
 	Knob         op.CallOp // Knob is used to render the knob of the radio button when it's selected.
 	KnobDisabled op.CallOp // KnobDisabled is used instead of Knob in disabled mode.
 	KnobSize     unit.Dp   // KnobSize is the diameter if the knob.
+
+	FocusRing FocusRing // FocusRing is drawn around the radio button while it's focused.
+
+	Label ControlLabel // Label is the text next to the radio button.
+
+	groupFocused bool // groupFocused is set by a focused RadioGroup to ring this radio button.
+	grouped      bool // grouped is set by a RadioGroup, which tracks hovering of its options.
+
+	hoverable
+
+	themed
 }
 
 // Layout lays the radio button out to the context.
 func (b *RadioButton) Layout(gtx layout.Context) layout.Dimensions {
-	var (
-		disabled = gtx.Queue == nil
-		active   = b.Group.Value == b.Key
-	)
+	if b.stale() {
+		b.theme.paintRadioButton(b)
+	}
+	if !b.grouped {
+		b.trackHover(gtx)
+	}
+	gtx, disabled := disable(gtx, b.Disabled)
+	active := b.Group.Value == b.Key
 	return b.Group.Layout(
 		gtx,
 		b.Key,
 		func(gtx layout.Context) layout.Dimensions {
 			semantic.RadioButton.Add(gtx.Ops)
-			return layout.Stack{Alignment: layout.Center}.Layout(
+			semantic.DisabledOp(disabled).Add(gtx.Ops)
+			b.addHover(gtx.Ops)
+			return b.Label.layout(
 				gtx,
-				layout.Expanded(
-					func(gtx layout.Context) layout.Dimensions {
-						var (
-							size  = gtx.Constraints.Min
-							shape = clip.Ellipse{Max: size}
-							path  = shape.Path(gtx.Ops)
-						)
-						func() {
-							defer shape.Push(gtx.Ops).Pop()
-							if disabled {
-								b.BackgroundDisabled.Add(gtx.Ops)
-							} else {
-								b.Background.Add(gtx.Ops)
-								if active {
-									b.Tint.Add(gtx.Ops)
-								}
-							}
-						}()
-						func() {
-							var stroke = clip.Stroke{
-								Width: float32(gtx.Dp(b.OutlineWidth)),
-								Path:  path,
-							}
-							defer stroke.Op().Push(gtx.Ops).Pop()
-							if disabled {
-								b.OutlineDisabled.Add(gtx.Ops)
-							} else {
-								b.Outline.Add(gtx.Ops)
-							}
-						}()
-						return layout.Dimensions{Size: size}
-					},
-				),
-				layout.Stacked(
-					func(gtx layout.Context) layout.Dimensions {
-						var inset = layout.UniformInset(b.Inset)
-						return inset.Layout(
-							gtx,
+				disabled,
+				func(gtx layout.Context) layout.Dimensions {
+					return layout.Stack{Alignment: layout.Center}.Layout(
+						gtx,
+						layout.Expanded(
 							func(gtx layout.Context) layout.Dimensions {
 								var (
-									diameter = gtx.Dp(b.KnobSize)
-									size     = image.Pt(diameter, diameter)
-									shape    = clip.Ellipse{Max: size}
+									size  = gtx.Constraints.Min
+									shape = clip.Ellipse{Max: size}
+									path  = shape.Path(gtx.Ops)
 								)
 								func() {
 									defer shape.Push(gtx.Ops).Pop()
-									if active {
-										if disabled {
-											b.KnobDisabled.Add(gtx.Ops)
-										} else {
-											b.Knob.Add(gtx.Ops)
+									if disabled {
+										b.BackgroundDisabled.Add(gtx.Ops)
+									} else {
+										b.Background.Add(gtx.Ops)
+										if active {
+											b.Tint.Add(gtx.Ops)
 										}
 									}
 								}()
+								func() {
+									var stroke = clip.Stroke{
+										Width: float32(gtx.Dp(b.OutlineWidth)),
+										Path:  path,
+									}
+									defer stroke.Op().Push(gtx.Ops).Pop()
+									if disabled {
+										b.OutlineDisabled.Add(gtx.Ops)
+									} else {
+										b.Outline.Add(gtx.Ops)
+									}
+								}()
+								if key, focused := b.Group.Focused(); (focused && key == b.Key || b.groupFocused) && !disabled {
+									b.FocusRing.draw(gtx, image.Rectangle{Max: size}, size.X/2)
+								}
 								return layout.Dimensions{Size: size}
 							},
-						)
-					},
-				),
+						),
+						layout.Stacked(
+							func(gtx layout.Context) layout.Dimensions {
+								var inset = layout.UniformInset(b.Inset)
+								return inset.Layout(
+									gtx,
+									func(gtx layout.Context) layout.Dimensions {
+										var (
+											diameter = gtx.Dp(b.KnobSize)
+											size     = image.Pt(diameter, diameter)
+											shape    = clip.Ellipse{Max: size}
+										)
+										func() {
+											defer shape.Push(gtx.Ops).Pop()
+											if active {
+												if disabled {
+													b.KnobDisabled.Add(gtx.Ops)
+												} else {
+													b.Knob.Add(gtx.Ops)
+												}
+											}
+										}()
+										return layout.Dimensions{Size: size}
+									},
+								)
+							},
+						),
+					)
+				},
 			)
 		},
 	)
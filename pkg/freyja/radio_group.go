@@ -0,0 +1,190 @@
+package freyja
+
+import (
+	"gioui.org/gesture"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/unit"
+	"gioui.org/widget"
+)
+
+// RadioOption is an option of a radio group.
+type RadioOption struct {
+	Key   string // Key is the key of the option in the group.
+	Label string // Label is the text shown next to the radio button of the option.
+}
+
+// RadioGroup is a set of radio buttons for mutually exclusive
+// options, laid out in a column or in a row.
+//
+// The group is a single Tab stop. While it has keyboard focus, arrow keys
+// select the previous or the next option, skipping disabled ones.
+type RadioGroup struct {
+	Origin   widget.Enum // Origin holds the key of the selected option.
+	Disabled bool        // Disabled shows every option disabled and makes them ignore input other than hovering.
+
+	Buttons []RadioButton // Buttons are the radio buttons of the options, their Group is set to Origin on Layout.
+
+	Axis    layout.Axis // Axis is the direction the options are laid out in.
+	Spacing unit.Dp     // Spacing is the gap between options.
+
+	clicks  []gesture.Click // clicks are the clicks of the options, which take no input on their own.
+	keyTag  struct{}
+	focused bool
+	changed bool
+}
+
+// radioGroupKeys are the keys that move the selection of radio groups.
+const radioGroupKeys = "[←,→,↑,↓]"
+
+// Layout lays the radio group out to the context.
+func (g *RadioGroup) Layout(gtx layout.Context) layout.Dimensions {
+	options, hovering := gtx, gtx
+	gtx, disabled := disable(gtx, g.Disabled)
+	if disabled {
+		// Options tell a disabled group from a plain context without a queue.
+		options = Disable(options)
+	} else {
+		// Options would be Tab stops of their own with a queue.
+		options.Queue = nil
+	}
+	for len(g.clicks) < len(g.Buttons) {
+		g.clicks = append(g.clicks, gesture.Click{})
+	}
+	g.update(gtx, disabled)
+	var (
+		children = make([]layout.FlexChild, 0, 2*len(g.Buttons))
+		spacer   = layout.Spacer{Height: g.Spacing}
+		record   = op.Record(gtx.Ops)
+	)
+	if g.Axis == layout.Horizontal {
+		spacer = layout.Spacer{Width: g.Spacing}
+	}
+	for i := range g.Buttons {
+		b := &g.Buttons[i]
+		b.Group = &g.Origin
+		b.groupFocused = g.focused && b.Key == g.Origin.Value
+		// Options are laid out without a queue, so the group tracks their hovering.
+		b.grouped = true
+		b.trackHover(hovering)
+		if i > 0 {
+			children = append(children, layout.Rigid(spacer.Layout))
+		}
+		click := &g.clicks[i]
+		children = append(
+			children,
+			layout.Rigid(
+				func(gtx layout.Context) layout.Dimensions {
+					record := op.Record(gtx.Ops)
+					dimensions := b.Layout(gtx)
+					call := record.Stop()
+					defer clip.Rect{Max: dimensions.Size}.Push(gtx.Ops).Pop()
+					if !b.Disabled {
+						click.Add(gtx.Ops)
+					}
+					call.Add(gtx.Ops)
+					return dimensions
+				},
+			),
+		)
+	}
+	dimensions := layout.Flex{Axis: g.Axis}.Layout(options, children...)
+	call := record.Stop()
+	defer clip.Rect{Max: dimensions.Size}.Push(gtx.Ops).Pop()
+	if !disabled {
+		key.InputOp{Tag: &g.keyTag, Keys: radioGroupKeys}.Add(gtx.Ops)
+	}
+	call.Add(gtx.Ops)
+	return dimensions
+}
+
+// Focused reports whether the group has keyboard focus.
+// Clicking an option focuses the group.
+func (g *RadioGroup) Focused() bool {
+	return g.focused
+}
+
+// Changed reports whether the selected option has changed by clicking,
+// by keyboard or by arrow keys since the last call to Changed.
+func (g *RadioGroup) Changed() bool {
+	changed := g.Origin.Changed() || g.changed
+	g.changed = false
+	return changed
+}
+
+// update handles the focus and the arrow keys of the group and the clicks of its options.
+func (g *RadioGroup) update(gtx layout.Context, disabled bool) {
+	for i := range g.Buttons {
+		for _, e := range g.clicks[i].Events(gtx) {
+			switch e.Type {
+			case gesture.TypePress:
+				if e.Source == pointer.Mouse {
+					key.FocusOp{Tag: &g.keyTag}.Add(gtx.Ops)
+				}
+			case gesture.TypeClick:
+				if option := g.Buttons[i].Key; option != g.Origin.Value {
+					g.Origin.Value = option
+					g.changed = true
+				}
+			}
+		}
+	}
+	for _, e := range gtx.Events(&g.keyTag) {
+		switch e := e.(type) {
+		case key.FocusEvent:
+			g.focused = e.Focus
+		case key.Event:
+			if e.State != key.Press {
+				break
+			}
+			switch e.Name {
+			case key.NameLeftArrow, key.NameUpArrow:
+				g.step(-1)
+			case key.NameRightArrow, key.NameDownArrow:
+				g.step(1)
+			}
+		}
+	}
+	if disabled {
+		g.focused = false
+	}
+}
+
+// step selects the enabled option that is delta options away from the selected one, wrapping around.
+func (g *RadioGroup) step(delta int) {
+	current := -1
+	for i := range g.Buttons {
+		if g.Buttons[i].Key == g.Origin.Value {
+			current = i
+		}
+	}
+	next := stepOption(len(g.Buttons), current, delta, func(i int) bool {
+		return g.Buttons[i].Disabled
+	})
+	if next < 0 {
+		return
+	}
+	if key := g.Buttons[next].Key; key != g.Origin.Value {
+		g.Origin.Value = key
+		g.changed = true
+	}
+}
+
+// stepOption returns the index of the enabled option that is delta options away
+// from the current one of count options, wrapping around, or -1 if every option
+// is disabled. The current index is -1 while no option is selected.
+func stepOption(count, current, delta int, disabled func(int) bool) int {
+	if current < 0 && delta < 0 {
+		current = count
+	}
+	for i := 1; i <= count; i++ {
+		option := ((current+delta*i)%count + count) % count
+		if !disabled(option) {
+			return option
+		}
+	}
+	return -1
+}
@@ -0,0 +1,69 @@
+package freyja_test
+
+import (
+	"image"
+	"testing"
+
+	"gioui.org/f32"
+	"gioui.org/io/key"
+	"gioui.org/io/router"
+	"gioui.org/layout"
+	"github.com/widetape/freyja/pkg/freyja"
+)
+
+func TestRadioGroup_Keys(t *testing.T) {
+	theme := newTheme()
+	group := theme.RadioGroup(
+		freyja.RadioOption{Key: "a", Label: "A"},
+		freyja.RadioOption{Key: "b", Label: "B"},
+		freyja.RadioOption{Key: "c", Label: "C"},
+	)
+	group.Buttons[1].Disabled = true
+	h := newHarness(layout.Constraints{Max: image.Pt(200, 200)}, group.Layout)
+	h.frame()
+	h.queue.MoveFocus(router.FocusForward)
+	for _, want := range []string{"a", "c", "a"} {
+		h.press(key.NameDownArrow)
+		if group.Origin.Value != want {
+			t.Errorf("selected %q, want %q", group.Origin.Value, want)
+		}
+		if !group.Changed() {
+			t.Error("change is not reported")
+		}
+	}
+	h.press(key.NameUpArrow)
+	if group.Origin.Value != "c" {
+		t.Errorf("selected %q after up arrow, want %q", group.Origin.Value, "c")
+	}
+}
+
+func TestRadioGroup_TabStop(t *testing.T) {
+	theme := newTheme()
+	group := theme.RadioGroup(
+		freyja.RadioOption{Key: "a", Label: "A"},
+		freyja.RadioOption{Key: "b", Label: "B"},
+		freyja.RadioOption{Key: "c", Label: "C"},
+	)
+	group.Buttons[1].Disabled = true
+	h := newHarness(layout.Constraints{Max: image.Pt(200, 200)}, group.Layout)
+	dimensions := h.frame()
+
+	// Click the last option, which focuses the group, and move the selection from it.
+	h.click(f32.Pt(5, float32(dimensions.Size.Y-5)))
+	h.frame()
+	if group.Origin.Value != "c" || !group.Focused() {
+		t.Fatalf("clicking selected %q with focus %v, want %q with focus", group.Origin.Value, group.Focused(), "c")
+	}
+	h.press(key.NameUpArrow)
+	if group.Origin.Value != "a" {
+		t.Errorf("selected %q after up arrow, want %q", group.Origin.Value, "a")
+	}
+
+	for i := 0; i < 2; i++ {
+		h.queue.MoveFocus(router.FocusForward)
+		h.frame()
+		if !group.Focused() {
+			t.Fatalf("group lost the focus after %d Tab presses, want it to be the only Tab stop", i+1)
+		}
+	}
+}
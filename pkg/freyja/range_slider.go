@@ -0,0 +1,206 @@
+package freyja
+
+import (
+	"image"
+
+	"gioui.org/gesture"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/io/semantic"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+)
+
+// RangeSlider is a slider with two knobs selecting an interval of values.
+//
+// The embedded Slider configures the range, the steps, the track, the tint,
+// the knobs and the ticks of the range slider, but its Origin is not used.
+// The knobs can't cross each other, so Low never exceeds High.
+type RangeSlider struct {
+	Slider
+
+	Low  float32 // Low is the start of the interval.
+	High float32 // High is the end of the interval.
+
+	drag    gesture.Drag
+	dragged *rangeKnob
+	shown   *rangeKnob
+	low     rangeKnob
+	high    rangeKnob
+}
+
+// rangeKnob is the state of a knob of a range slider.
+type rangeKnob struct {
+	focused bool
+}
+
+// Layout lays the range slider out to the context.
+func (s *RangeSlider) Layout(gtx layout.Context) layout.Dimensions {
+	if s.stale() {
+		s.theme.paintSlider(&s.Slider)
+	}
+	s.trackHover(gtx)
+	gtx, disabled := disable(gtx, s.Disabled)
+	s.update(gtx, disabled)
+	return s.inset().Layout(
+		gtx,
+		func(gtx layout.Context) layout.Dimensions {
+			var (
+				knobSize = gtx.Dp(s.KnobSize)
+				length   = s.Axis.Convert(gtx.Constraints.Max).X
+				size     = s.Axis.Convert(image.Pt(length, knobSize))
+			)
+			s.Low, s.High = s.snap(s.Low), s.snap(s.High)
+			if s.crossed(s.Low, s.High) {
+				s.High = s.Low
+			}
+			s.track(gtx, length, disabled)
+			s.slide(gtx, length, size, disabled)
+			s.tint(gtx, s.position(s.Low, length), s.position(s.High, length), disabled)
+			if s.Ticks > 0 {
+				size = size.Add(s.Axis.Convert(image.Pt(0, s.ticks(gtx, length, knobSize, disabled))))
+			}
+			for _, k := range []*rangeKnob{&s.low, &s.high} {
+				position := s.position(*s.field(k), length)
+				s.knob(gtx, position, k.focused, disabled)
+				s.focus(gtx, k, position, disabled)
+			}
+			if s.dragged != nil {
+				s.shown = s.dragged
+			}
+			if s.shown != nil {
+				value := *s.field(s.shown)
+				s.showBubble(gtx, value, s.position(value, length), s.dragged != nil && !disabled)
+			}
+			s.addHoverArea(gtx.Ops, size)
+			return layout.Dimensions{
+				Size: size,
+			}
+		},
+	)
+}
+
+// Focused reports whether any knob of the range slider has keyboard focus.
+func (s *RangeSlider) Focused() bool {
+	return s.low.focused || s.high.focused
+}
+
+// update handles the focus and the keys of the knobs.
+func (s *RangeSlider) update(gtx layout.Context, disabled bool) {
+	for _, k := range []*rangeKnob{&s.low, &s.high} {
+		for _, e := range gtx.Events(k) {
+			switch e := e.(type) {
+			case key.FocusEvent:
+				k.focused = e.Focus
+			case key.Event:
+				s.move(k, s.press(e, *s.field(k)))
+			}
+		}
+		if disabled {
+			k.focused = false
+		}
+	}
+}
+
+// slide handles dragging of the knobs over the area of the size.
+func (s *RangeSlider) slide(gtx layout.Context, length int, size image.Point, disabled bool) {
+	for _, e := range s.drag.Events(gtx.Metric, gtx, gesture.Axis(s.Axis)) {
+		if e.Type != pointer.Press && e.Type != pointer.Drag {
+			continue
+		}
+		position := e.Position.X
+		if s.Axis == layout.Vertical {
+			position = e.Position.Y
+		}
+		value := s.snap(s.value(position, length))
+		if e.Type == pointer.Press || s.dragged == nil {
+			s.dragged = s.nearest(value, int(position), length)
+			key.FocusOp{Tag: s.dragged}.Add(gtx.Ops)
+		}
+		s.move(s.dragged, value)
+	}
+	if !s.drag.Dragging() && !s.drag.Pressed() {
+		s.dragged = nil
+	}
+	if disabled {
+		return
+	}
+	margin := s.Axis.Convert(image.Pt(gtx.Dp(s.KnobSize), 0))
+	defer clip.Rect{Min: margin.Mul(-1), Max: size.Add(margin)}.Push(gtx.Ops).Pop()
+	s.drag.Add(gtx.Ops)
+}
+
+// focus describes the value of the knob at the position to screen readers and makes the knob focusable.
+func (s *RangeSlider) focus(gtx layout.Context, k *rangeKnob, position int, disabled bool) {
+	knobSize := gtx.Dp(s.KnobSize)
+	defer op.Offset(s.Axis.Convert(image.Pt(position-knobSize/2, 0))).Push(gtx.Ops).Pop()
+	defer clip.Rect{Max: image.Pt(knobSize, knobSize)}.Push(gtx.Ops).Pop()
+	semantic.DescriptionOp(s.describe(*s.field(k))).Add(gtx.Ops)
+	semantic.DisabledOp(disabled).Add(gtx.Ops)
+	if !disabled {
+		key.InputOp{Tag: k, Keys: sliderKeys}.Add(gtx.Ops)
+	}
+}
+
+// nearest returns the knob to drag to the value pressed at the position.
+func (s *RangeSlider) nearest(value float32, position, length int) *rangeKnob {
+	var (
+		low  = s.position(s.Low, length) - position
+		high = s.position(s.High, length) - position
+	)
+	if low < 0 {
+		low = -low
+	}
+	if high < 0 {
+		high = -high
+	}
+	switch {
+	case low < high:
+		return &s.low
+	case high < low:
+		return &s.high
+	case value >= s.High:
+		// The knobs overlap, so pick the one that can move towards the value.
+		return &s.high
+	default:
+		return &s.low
+	}
+}
+
+// move moves the knob to the value without crossing the other knob.
+func (s *RangeSlider) move(k *rangeKnob, value float32) {
+	low, high := s.Low, s.High
+	if k == &s.low {
+		low = value
+		if s.crossed(low, high) {
+			low = high
+		}
+	} else {
+		high = value
+		if s.crossed(low, high) {
+			high = low
+		}
+	}
+	if low != s.Low || high != s.High {
+		s.Low, s.High = low, high
+		s.changed = true
+	}
+}
+
+// crossed reports whether the low value is past the high one.
+func (s *RangeSlider) crossed(low, high float32) bool {
+	min, max := s.bounds()
+	if min > max {
+		return low < high
+	}
+	return low > high
+}
+
+// field returns the value of the knob.
+func (s *RangeSlider) field(k *rangeKnob) *float32 {
+	if k == &s.low {
+		return &s.Low
+	}
+	return &s.High
+}
@@ -0,0 +1,65 @@
+package freyja_test
+
+import (
+	"image"
+	"testing"
+
+	"gioui.org/f32"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/layout"
+	"github.com/widetape/freyja/pkg/freyja"
+)
+
+func TestRangeSlider_Crossing(t *testing.T) {
+	slider := freyja.RangeSlider{
+		Slider: freyja.Slider{KnobSize: 20, Min: 0, Max: 100, Step: 10},
+		Low:    20,
+		High:   60,
+	}
+	h := newHarness(layout.Constraints{Max: image.Pt(220, 20)}, slider.Layout)
+	h.frame()
+	// Drag the low knob far past the high one.
+	h.queue.Queue(
+		pointer.Event{Type: pointer.Press, Source: pointer.Mouse, Buttons: pointer.ButtonPrimary, Position: f32.Pt(50, 10)},
+		pointer.Event{Type: pointer.Move, Source: pointer.Mouse, Buttons: pointer.ButtonPrimary, Position: f32.Pt(190, 10)},
+	)
+	h.frame()
+	if slider.Low != 60 || slider.High != 60 {
+		t.Fatalf("got interval %v–%v, want 60–60", slider.Low, slider.High)
+	}
+	h.queue.Queue(pointer.Event{Type: pointer.Release, Source: pointer.Mouse, Position: f32.Pt(190, 10)})
+	h.frame()
+	if !slider.Changed() {
+		t.Error("dragging did not change the range slider")
+	}
+	// The pressed knob took the focus, so the keys move it.
+	h.press(key.NameLeftArrow)
+	if slider.Low != 50 || slider.High != 60 {
+		t.Fatalf("got interval %v–%v, want 50–60", slider.Low, slider.High)
+	}
+}
+
+func TestRangeSlider_Description(t *testing.T) {
+	slider := freyja.RangeSlider{
+		Slider: freyja.Slider{KnobSize: 20, Min: 0, Max: 100},
+		Low:    20,
+		High:   60,
+	}
+	h := newHarness(layout.Constraints{Max: image.Pt(220, 20)}, slider.Layout)
+	h.frame()
+	bounds := map[string]image.Rectangle{}
+	for _, node := range h.queue.AppendSemantics(nil) {
+		if node.Desc.Description != "" {
+			bounds[node.Desc.Description] = node.Desc.Bounds
+		}
+	}
+	for description, want := range map[string]image.Rectangle{
+		"20": image.Rect(40, 0, 60, 20),
+		"60": image.Rect(120, 0, 140, 20),
+	} {
+		if bounds[description] != want {
+			t.Errorf("knob %s is described over %v, want %v", description, bounds[description], want)
+		}
+	}
+}
@@ -0,0 +1,401 @@
+package freyja
+
+import (
+	"image"
+	"image/color"
+	"time"
+
+	"gioui.org/font"
+	"gioui.org/gesture"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/io/semantic"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/text"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"github.com/widetape/freyja/pkg/freyja/anim"
+)
+
+// Segment is a segment of a segmented control.
+type Segment struct {
+	Key      string // Key is the key of this segment in the Origin of the control.
+	Label    string // Label is the text of this segment.
+	Disabled bool   // Disabled shows the segment disabled and makes it ignore input other than hovering.
+
+	Value widget.Bool // Value is toggled by the segment in multiple selection mode.
+
+	click     gesture.Click // click is the click of the segment in single selection mode.
+	highlight highlight
+
+	hoverable
+}
+
+// SegmentedControl is a row of joined segments of equal width where one segment,
+// or any number of them in multiple selection mode, is selected.
+//
+// In single selection mode an indicator slides to the selected segment and,
+// like a RadioGroup, the control is a single Tab stop whose arrow keys select
+// the previous or the next segment, skipping disabled ones. In multiple
+// selection mode every segment is a Tab stop that toggles its Value.
+type SegmentedControl struct {
+	Origin   widget.Enum // Origin holds the key of the selected segment in single selection mode.
+	Multiple bool        // Multiple selects segments independently in their Value instead of Origin.
+	Disabled bool        // Disabled shows every segment disabled and makes them ignore input other than hovering.
+
+	Segments []Segment // Segments are the segments from left to right.
+
+	Background         op.CallOp // Background is called to fill the background of this control.
+	BackgroundDisabled op.CallOp // BackgroundDisabled is used instead of Background in disabled mode.
+	CornerRadius       unit.Dp   // CornerRadius is the radius of the outer corners of this control.
+
+	Tint         op.CallOp // Tint is called to fill selected segments.
+	TintDisabled op.CallOp // TintDisabled is used instead of Tint in disabled mode.
+
+	Divider      color.NRGBA // Divider is the color of lines between unselected segments, there are no lines if it's transparent.
+	DividerWidth unit.Dp     // DividerWidth is the width of lines between segments.
+
+	Shadow Shadows // Shadow is the shadow casted by this control.
+
+	FocusRing FocusRing // FocusRing is drawn around the focused segment.
+
+	Inset layout.Inset // Inset is used to margin labels from the borders of segments.
+
+	Shaper   *text.Shaper // Shaper is used to layout labels.
+	Font     font.Font    // Font is used for labels.
+	FontSize unit.Sp      // FontSize is the size of labels.
+
+	Foreground         op.CallOp // Foreground is the material operation for labels.
+	ForegroundSelected op.CallOp // ForegroundSelected is used instead of Foreground for labels of selected segments.
+	ForegroundDisabled op.CallOp // ForegroundDisabled is used instead of Foreground in disabled mode.
+
+	HoverColor color.NRGBA // HoverColor is drawn over a segment when it's hovered.
+	ClickColor color.NRGBA // ClickColor is drawn over a segment while it's being pressed.
+
+	FadeDuration time.Duration // FadeDuration is how long HoverColor and ClickColor take to fade in and out.
+
+	Duration time.Duration // Duration is how long the indicator takes to slide to a newly selected segment.
+	Easing   anim.Easing   // Easing is the curve of the slide, linear if nil.
+
+	indicator anim.Tween[image.Rectangle] // indicator is the area of the selection indicator.
+	keyTag    struct{}
+	focused   bool
+	changed   bool
+
+	themed
+}
+
+// Layout lays the segmented control out to the context.
+func (c *SegmentedControl) Layout(gtx layout.Context) layout.Dimensions {
+	if c.stale() {
+		c.theme.paintSegmentedControl(c)
+	}
+	for i := range c.Segments {
+		c.Segments[i].trackHover(gtx)
+	}
+	gtx, disabled := disable(gtx, c.Disabled)
+	c.update(gtx, disabled)
+	count := len(c.Segments)
+	if count == 0 {
+		return layout.Dimensions{Size: gtx.Constraints.Min}
+	}
+	var (
+		size   = c.segmentSize(gtx)
+		bounds = image.Rectangle{Max: image.Pt(size.X*count, size.Y)}
+		radius = gtx.Dp(c.CornerRadius)
+		shape  = clip.UniformRRect(bounds, radius)
+		shadow = c.Shadow
+	)
+	if disabled {
+		shadow = nil
+	}
+	record := op.Record(gtx.Ops)
+	shadow.LayoutRRect(
+		gtx,
+		shape,
+		func(gtx layout.Context) layout.Dimensions {
+			defer shape.Push(gtx.Ops).Pop()
+			if disabled {
+				c.BackgroundDisabled.Add(gtx.Ops)
+			} else {
+				c.Background.Add(gtx.Ops)
+			}
+			c.indicate(gtx, size, disabled)
+			c.dividers(gtx, size)
+			for i := range c.Segments {
+				c.segment(gtx, i, size, disabled)
+			}
+			return layout.Dimensions{Size: bounds.Max}
+		},
+	)
+	call := record.Stop()
+	func() {
+		defer clip.Rect(bounds).Push(gtx.Ops).Pop()
+		if !disabled && !c.Multiple {
+			key.InputOp{Tag: &c.keyTag, Keys: radioGroupKeys}.Add(gtx.Ops)
+		}
+		call.Add(gtx.Ops)
+	}()
+	if disabled {
+		return layout.Dimensions{Size: bounds.Max}
+	}
+	for i := range c.Segments {
+		s := &c.Segments[i]
+		if s.Disabled {
+			continue
+		}
+		if c.Multiple && s.Value.Focused() || !c.Multiple && c.focused && s.Key == c.Origin.Value {
+			c.FocusRing.draw(gtx, c.segmentBounds(i, size), radius)
+		}
+	}
+	return layout.Dimensions{Size: bounds.Max}
+}
+
+// Focused reports whether the control has keyboard focus in single selection mode.
+// Clicking a segment focuses the control.
+func (c *SegmentedControl) Focused() bool {
+	return c.focused
+}
+
+// Changed reports whether the selection has changed by clicking
+// or by keyboard since the last call to Changed.
+func (c *SegmentedControl) Changed() bool {
+	changed := c.Origin.Changed() || c.changed
+	c.changed = false
+	return changed
+}
+
+// Selected reports whether the segment is selected.
+func (c *SegmentedControl) Selected(s *Segment) bool {
+	if c.Multiple {
+		return s.Value.Value
+	}
+	return s.Key == c.Origin.Value
+}
+
+// update handles the focus and the arrow keys of the control and the clicks of
+// its segments in single selection mode.
+func (c *SegmentedControl) update(gtx layout.Context, disabled bool) {
+	for i := range c.Segments {
+		for _, e := range c.Segments[i].click.Events(gtx) {
+			switch e.Type {
+			case gesture.TypePress:
+				if e.Source == pointer.Mouse {
+					key.FocusOp{Tag: &c.keyTag}.Add(gtx.Ops)
+				}
+			case gesture.TypeClick:
+				c.selectSegment(i)
+			}
+		}
+	}
+	for _, e := range gtx.Events(&c.keyTag) {
+		switch e := e.(type) {
+		case key.FocusEvent:
+			c.focused = e.Focus
+		case key.Event:
+			if e.State != key.Press {
+				break
+			}
+			switch e.Name {
+			case key.NameLeftArrow, key.NameUpArrow:
+				c.step(-1)
+			case key.NameRightArrow, key.NameDownArrow:
+				c.step(1)
+			}
+		}
+	}
+	if disabled || c.Multiple {
+		c.focused = false
+	}
+}
+
+// step selects the enabled segment that is delta segments away from the selected one, wrapping around.
+func (c *SegmentedControl) step(delta int) {
+	current := -1
+	for i := range c.Segments {
+		if c.Segments[i].Key == c.Origin.Value {
+			current = i
+		}
+	}
+	c.selectSegment(stepOption(len(c.Segments), current, delta, func(i int) bool {
+		return c.Segments[i].Disabled
+	}))
+}
+
+// selectSegment selects the segment with the index in single selection mode, if there is one.
+func (c *SegmentedControl) selectSegment(index int) {
+	if index < 0 {
+		return
+	}
+	if key := c.Segments[index].Key; key != c.Origin.Value {
+		c.Origin.Value = key
+		c.changed = true
+	}
+}
+
+// segmentSize returns the size of every segment: the size of the largest label,
+// stretched to fill the minimum constraints of the control.
+func (c *SegmentedControl) segmentSize(gtx layout.Context) image.Point {
+	var (
+		count = len(c.Segments)
+		size  = image.Pt(gtx.Constraints.Min.X/count, gtx.Constraints.Min.Y)
+		lgtx  = gtx
+	)
+	lgtx.Constraints.Min = image.Point{}
+	for i := range c.Segments {
+		// Labels are measured here and laid out again by segment.
+		record := op.Record(gtx.Ops)
+		dimensions := c.label(lgtx, c.Segments[i].Label, op.CallOp{})
+		record.Stop()
+		if dimensions.Size.X > size.X {
+			size.X = dimensions.Size.X
+		}
+		if dimensions.Size.Y > size.Y {
+			size.Y = dimensions.Size.Y
+		}
+	}
+	return size
+}
+
+// segmentBounds returns the bounds of the segment with the index.
+func (c *SegmentedControl) segmentBounds(index int, size image.Point) image.Rectangle {
+	return image.Rectangle{Max: size}.Add(image.Pt(index*size.X, 0))
+}
+
+// label lays out the text with the inset.
+func (c *SegmentedControl) label(gtx layout.Context, text string, color op.CallOp) layout.Dimensions {
+	return c.Inset.Layout(
+		gtx,
+		func(gtx layout.Context) layout.Dimensions {
+			return widget.Label{MaxLines: 1}.Layout(
+				gtx,
+				c.Shaper,
+				c.Font,
+				c.FontSize,
+				text,
+				color,
+			)
+		},
+	)
+}
+
+// indicate fills the selected segments with the tint. In single selection
+// mode the tint is an indicator that slides to the selected segment.
+func (c *SegmentedControl) indicate(gtx layout.Context, size image.Point, disabled bool) {
+	tint := c.Tint
+	if disabled {
+		tint = c.TintDisabled
+	}
+	if c.Multiple {
+		for i := range c.Segments {
+			if c.Segments[i].Value.Value {
+				area := clip.Rect(c.segmentBounds(i, size)).Push(gtx.Ops)
+				tint.Add(gtx.Ops)
+				area.Pop()
+			}
+		}
+		return
+	}
+	selected := -1
+	for i := range c.Segments {
+		if c.Segments[i].Key == c.Origin.Value {
+			selected = i
+		}
+	}
+	if selected < 0 {
+		// The indicator appears at the next selected segment without sliding.
+		c.indicator = anim.Tween[image.Rectangle]{}
+		return
+	}
+	c.indicator.Duration = c.Duration
+	c.indicator.Easing = c.Easing
+	indicator := c.indicator.Animate(gtx, c.segmentBounds(selected, size))
+	defer clip.Rect(indicator).Push(gtx.Ops).Pop()
+	tint.Add(gtx.Ops)
+}
+
+// dividers draws lines between segments where neither of them is selected.
+func (c *SegmentedControl) dividers(gtx layout.Context, size image.Point) {
+	if c.Divider.A == 0 {
+		return
+	}
+	width := gtx.Dp(c.DividerWidth)
+	for i := 1; i < len(c.Segments); i++ {
+		if c.Selected(&c.Segments[i-1]) || c.Selected(&c.Segments[i]) {
+			continue
+		}
+		var (
+			x    = i*size.X - width/2
+			line = image.Rect(x, 0, x+width, size.Y)
+		)
+		paint.FillShape(gtx.Ops, c.Divider, clip.Rect(line).Op())
+	}
+}
+
+// segment lays out the segment with the index. In single selection mode
+// the segment takes no input on its own, the control handles its clicks.
+func (c *SegmentedControl) segment(gtx layout.Context, index int, size image.Point, disabled bool) {
+	s := &c.Segments[index]
+	gtx, disabled = disable(gtx, disabled || s.Disabled)
+	gtx.Constraints = layout.Exact(size)
+	defer op.Offset(image.Pt(index*size.X, 0)).Push(gtx.Ops).Pop()
+	content := func(gtx layout.Context) layout.Dimensions {
+		selected := c.Selected(s)
+		if c.Multiple {
+			semantic.CheckBox.Add(gtx.Ops)
+		} else {
+			semantic.RadioButton.Add(gtx.Ops)
+		}
+		semantic.SelectedOp(selected).Add(gtx.Ops)
+		semantic.DisabledOp(disabled).Add(gtx.Ops)
+		s.addHover(gtx.Ops)
+		if disabled {
+			s.highlight.reset()
+		} else {
+			pressed := s.click.Pressed()
+			if c.Multiple {
+				pressed = s.Value.Pressed()
+			}
+			s.highlight.draw(
+				gtx,
+				s.Hovered(),
+				pressed,
+				c.HoverColor,
+				c.ClickColor,
+				c.FadeDuration,
+			)
+		}
+		var color op.CallOp
+		switch {
+		case disabled:
+			color = c.ForegroundDisabled
+		case selected:
+			color = c.ForegroundSelected
+		default:
+			color = c.Foreground
+		}
+		return layout.Center.Layout(
+			gtx,
+			func(gtx layout.Context) layout.Dimensions {
+				return c.label(gtx, s.Label, color)
+			},
+		)
+	}
+	if c.Multiple {
+		s.Value.Layout(gtx, content)
+		if s.Value.Changed() {
+			c.changed = true
+		}
+		return
+	}
+	defer clip.Rect{Max: size}.Push(gtx.Ops).Pop()
+	if !disabled {
+		s.click.Add(gtx.Ops)
+	}
+	// Segments would be Tab stops of their own with a queue.
+	gtx.Queue = nil
+	c.Origin.Layout(gtx, s.Key, content)
+}
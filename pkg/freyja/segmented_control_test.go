@@ -0,0 +1,71 @@
+package freyja_test
+
+import (
+	"image"
+	"testing"
+
+	"gioui.org/f32"
+	"gioui.org/io/key"
+	"gioui.org/io/router"
+	"gioui.org/layout"
+	"github.com/widetape/freyja/pkg/freyja"
+)
+
+func TestSegmentedControl_Select(t *testing.T) {
+	theme := newTheme()
+	control := theme.SegmentedControl(
+		freyja.RadioOption{Key: "day", Label: "Day"},
+		freyja.RadioOption{Key: "week", Label: "Week"},
+		freyja.RadioOption{Key: "month", Label: "Month"},
+	)
+	control.Origin.Value = "day"
+	h := newHarness(layout.Exact(image.Pt(300, 30)), control.Layout)
+	h.frame()
+	h.click(f32.Pt(250, 15))
+	if control.Origin.Value != "month" || !control.Changed() {
+		t.Errorf("selected %q, want %q", control.Origin.Value, "month")
+	}
+
+	control.Multiple = true
+	h.frame()
+	h.click(f32.Pt(150, 15))
+	h.click(f32.Pt(250, 15))
+	if !control.Segments[1].Value.Value || !control.Segments[2].Value.Value || control.Segments[0].Value.Value {
+		t.Error("segments are not selected independently in multiple selection mode")
+	}
+	if !control.Changed() {
+		t.Error("change is not reported in multiple selection mode")
+	}
+}
+
+func TestSegmentedControl_Keys(t *testing.T) {
+	theme := newTheme()
+	control := theme.SegmentedControl(
+		freyja.RadioOption{Key: "day", Label: "Day"},
+		freyja.RadioOption{Key: "week", Label: "Week"},
+		freyja.RadioOption{Key: "month", Label: "Month"},
+	)
+	control.Segments[1].Disabled = true
+	h := newHarness(layout.Exact(image.Pt(300, 30)), control.Layout)
+	h.frame()
+	for i := 0; i < 2; i++ {
+		h.queue.MoveFocus(router.FocusForward)
+		h.frame()
+		if !control.Focused() {
+			t.Fatalf("control lost the focus after %d Tab presses, want it to be the only Tab stop", i+1)
+		}
+	}
+	for _, want := range []string{"day", "month", "day"} {
+		h.press(key.NameRightArrow)
+		if control.Origin.Value != want {
+			t.Errorf("selected %q, want %q", control.Origin.Value, want)
+		}
+		if !control.Changed() {
+			t.Error("change is not reported")
+		}
+	}
+	h.press(key.NameLeftArrow)
+	if control.Origin.Value != "month" {
+		t.Errorf("selected %q after left arrow, want %q", control.Origin.Value, "month")
+	}
+}
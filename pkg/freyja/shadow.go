@@ -5,6 +5,7 @@ import (
 	"image/color"
 	"math"
 
+	"gioui.org/f32"
 	"gioui.org/layout"
 	"gioui.org/op"
 	"gioui.org/op/clip"
@@ -14,49 +15,434 @@ import (
 
 // Shadow draws a component and a shadow below it.
 //
-// Note that non-opaque components may produce unexpected look.
+// By default the shadow is made of Layers stroked outlines of the
+// component, which fade out with the distance from it. A Soft shadow is
+// a blurred image of the component instead: it looks smooth at any Spread
+// and costs a single image per frame, but it's only cast by rounded
+// rectangles laid out with LayoutRRect.
+//
+// An Inset shadow is cast into the component instead, as if it was carved,
+// and is drawn over the content, only inside the shape.
+//
+// LayoutRRect cuts the shape out of the other shadows, so translucent
+// components show what's behind them rather than their own shadow.
+// Layout can't cut out an arbitrary path and draws half of every
+// stroked layer below the component.
 type Shadow struct {
 	Color  color.NRGBA // Color is the basic of the shadow.
 	Layers int         // Layers determines how many shadow layer to draw.
-	Spread unit.Dp     // Spread determines how far the shadow goes from the content.
+	Spread unit.Dp     // Spread determines how far the shadow goes from the content, it's the blur radius of soft shadows.
 	X      unit.Dp     // X is the horizontal offset of this shadow.
 	Y      unit.Dp     // Y is the vertical offset of this shadow.
 	Slope  float64
+	Soft   bool // Soft blurs the shadow instead of stroking layers, Layers and Slope don't affect soft shadows.
+	Inset  bool // Inset casts the shadow inside the shape instead of around it.
+}
+
+// Shadows are shadows cast by a component together, usually a sharp key
+// shadow and a wide ambient one. The first shadow is drawn at the bottom.
+//
+// Shadow fields of widgets are Shadows, a single shadow is Shadows{shadow}.
+type Shadows []Shadow
+
+// strokedShadows are the recorded stroked shadows of rounded rectangles.
+var strokedShadows = cache[strokedShadowKey, op.CallOp]{capacity: 256}
+
+// strokedShadowKey is what the recording of a stroked shadow depends on.
+type strokedShadowKey struct {
+	shadow Shadow
+	shape  clip.RRect
+	metric unit.Metric
+}
+
+// softShadowMasks are the blurred coverages of soft shadows. They don't depend
+// on the color, so shadows that fade in and out are only colored again.
+var softShadowMasks = cache[softShadowKey, *image.Alpha]{capacity: 64}
+
+// softShadows are the images of soft shadows.
+var softShadows = cache[softShadowImageKey, paint.ImageOp]{capacity: 64}
+
+// softShadowKey is what the coverage of a soft shadow depends on.
+type softShadowKey struct {
+	size  image.Point
+	radii [4]int // radii are the radii of corners, clockwise from the top left one.
+	blur  int
+	inset bool
+	shift image.Point // shift is the offset of the rectangle in the image.
+	hole  image.Point // hole is the offset of the rectangle cut out of the shadow, if it's not inset.
+}
+
+// softShadowImageKey is what the image of a soft shadow depends on.
+type softShadowImageKey struct {
+	mask  softShadowKey
+	color color.NRGBA
+}
+
+// opacitySteps is the number of opacities faded shadows take,
+// so a fade reuses a few images instead of making one every frame.
+const opacitySteps = 16
+
+// stepped rounds the opacity to the nearest of opacitySteps steps.
+func stepped(opacity float32) float32 {
+	return float32(math.Round(float64(opacity*opacitySteps))) / opacitySteps
 }
 
 // Layout lays out the content and a shadow below it.
+//
+// Layout strokes the shadow in every frame: the path of the shape is
+// recorded into the ops of the frame, so it can't be told apart from
+// a changed one. Shadows of rounded rectangles are cached by LayoutRRect.
 func (s *Shadow) Layout(gtx layout.Context, shape clip.PathSpec, content layout.Widget) layout.Dimensions {
 	contentRecord := op.Record(gtx.Ops)
 	contentDimensions := content(gtx)
 	contentOp := contentRecord.Stop()
-	func() {
+	if s.Inset {
+		contentOp.Add(gtx.Ops)
+		defer clip.Outline{Path: shape}.Op().Push(gtx.Ops).Pop()
+		s.stroke(gtx, shape)
+		return contentDimensions
+	}
+	s.stroke(gtx, shape)
+	contentOp.Add(gtx.Ops)
+	return contentDimensions
+}
+
+// stroke draws the layers of the shadow along the shape.
+func (s *Shadow) stroke(gtx layout.Context, shape clip.PathSpec) {
+	var (
+		spread = gtx.Dp(s.Spread)
+		offset = op.Offset(
+			image.Pt(
+				gtx.Dp(s.X),
+				gtx.Dp(s.Y),
+			),
+		)
+	)
+	defer offset.Push(gtx.Ops).Pop()
+	for i := 0; i < s.Layers; i++ {
 		var (
-			spread = gtx.Dp(s.Spread)
-			offset = op.Offset(
-				image.Pt(
-					gtx.Dp(s.X),
-					gtx.Dp(s.Y),
-				),
-			)
+			distance = float32(i+1) / float32(s.Layers)
+			width    = float32(spread) * float32(1-math.Exp(-1*math.Pow(float64(distance), s.Slope)))
+			alpha    = float32(s.Color.A) * distance
+			color    = color.NRGBA{
+				R: s.Color.R,
+				G: s.Color.G,
+				B: s.Color.B,
+				A: uint8(alpha * (1 - distance)),
+			}
 		)
-		defer offset.Push(gtx.Ops).Pop()
-		for i := 0; i < s.Layers; i++ {
+		shadowLayer(gtx.Ops, width, shape, color)
+	}
+}
+
+// LayoutRRect lays out the content and a shadow of the rounded rectangle below it.
+//
+// Soft shadows are only drawn by LayoutRRect, Layout strokes them as usual.
+// Unlike Layout, LayoutRRect records the shadow once and reuses it in later
+// frames while the rectangle, the metric and the shadow stay the same.
+func (s *Shadow) LayoutRRect(gtx layout.Context, shape clip.RRect, content layout.Widget) layout.Dimensions {
+	contentRecord := op.Record(gtx.Ops)
+	contentDimensions := content(gtx)
+	contentOp := contentRecord.Stop()
+	if s.Inset {
+		contentOp.Add(gtx.Ops)
+		s.rrect(gtx, shape)
+		return contentDimensions
+	}
+	s.rrect(gtx, shape)
+	contentOp.Add(gtx.Ops)
+	return contentDimensions
+}
+
+// rrect draws the shadow of the rounded rectangle from the cache.
+func (s *Shadow) rrect(gtx layout.Context, shape clip.RRect) {
+	if s.Soft {
+		s.soft(gtx, shape)
+		return
+	}
+	if s.Color.A == 0 || s.Layers <= 0 {
+		return
+	}
+	if !s.Inset {
+		// The area around the hole only needs to cover the shadow.
+		offset := math.Abs(float64(s.X)) + math.Abs(float64(s.Y))
+		margin := gtx.Dp(s.Spread+unit.Dp(offset)) + 1
+		defer clip.Outline{Path: cutout(gtx.Ops, shape, margin)}.Op().Push(gtx.Ops).Pop()
+	}
+	key := strokedShadowKey{shadow: *s, shape: shape, metric: gtx.Metric}
+	strokedShadows.get(key, strokedShadow).Add(gtx.Ops)
+}
+
+// strokedShadow records the stroked shadow of the key into its own ops.
+func strokedShadow(key strokedShadowKey) op.CallOp {
+	var (
+		ops    = new(op.Ops)
+		gtx    = layout.Context{Metric: key.metric, Ops: ops}
+		record = op.Record(ops)
+		path   = key.shape.Path(ops)
+	)
+	if key.shadow.Inset {
+		area := clip.Outline{Path: path}.Op().Push(ops)
+		key.shadow.stroke(gtx, path)
+		area.Pop()
+	} else {
+		key.shadow.stroke(gtx, path)
+	}
+	return record.Stop()
+}
+
+// Layout lays out the content and the shadows below it.
+// Unlike LayoutRRect, it doesn't cut the shape out of the shadows.
+func (s Shadows) Layout(gtx layout.Context, shape clip.PathSpec, content layout.Widget) layout.Dimensions {
+	if len(s) == 0 {
+		return content(gtx)
+	}
+	return s[0].Layout(
+		gtx,
+		shape,
+		func(gtx layout.Context) layout.Dimensions {
+			return s[1:].Layout(gtx, shape, content)
+		},
+	)
+}
+
+// LayoutRRect lays out the content and the shadows of the rounded rectangle below it.
+func (s Shadows) LayoutRRect(gtx layout.Context, shape clip.RRect, content layout.Widget) layout.Dimensions {
+	if len(s) == 0 {
+		return content(gtx)
+	}
+	return s[0].LayoutRRect(
+		gtx,
+		shape,
+		func(gtx layout.Context) layout.Dimensions {
+			return s[1:].LayoutRRect(gtx, shape, content)
+		},
+	)
+}
+
+// fade returns a copy of the shadows with colors faded by the opacity,
+// which is stepped to keep the images of soft shadows cached.
+func (s Shadows) fade(opacity float32) Shadows {
+	opacity = stepped(opacity)
+	faded := make(Shadows, len(s))
+	for i, shadow := range s {
+		shadow.Color = fade(shadow.Color, opacity)
+		faded[i] = shadow
+	}
+	return faded
+}
+
+// soft draws the blurred image of the rounded rectangle with the rectangle
+// cut out of it, or of everything around it for inset shadows.
+func (s *Shadow) soft(gtx layout.Context, shape clip.RRect) {
+	if s.Color.A == 0 || shape.Rect.Empty() {
+		return
+	}
+	key := s.softKey(gtx, shape)
+	if s.Inset {
+		// The image covers the shape, where the shifted rectangle casts its shadow.
+		defer shape.Push(gtx.Ops).Pop()
+		defer op.Offset(shape.Rect.Min).Push(gtx.Ops).Pop()
+	} else {
+		defer op.Offset(shape.Rect.Min.Sub(key.hole)).Push(gtx.Ops).Pop()
+	}
+	shadow := softShadows.get(softShadowImageKey{mask: key, color: s.Color}, softShadowImage)
+	defer clip.Rect{Max: shadow.Size()}.Push(gtx.Ops).Pop()
+	shadow.Add(gtx.Ops)
+	paint.PaintOp{}.Add(gtx.Ops)
+}
+
+// softKey returns the key of the coverage of the soft shadow of the rounded rectangle.
+func (s *Shadow) softKey(gtx layout.Context, shape clip.RRect) softShadowKey {
+	var (
+		key = softShadowKey{
+			size:  shape.Rect.Size(),
+			radii: [4]int{shape.NW, shape.NE, shape.SE, shape.SW},
+			blur:  gtx.Dp(s.Spread),
+			inset: s.Inset,
+		}
+		offset = image.Pt(gtx.Dp(s.X), gtx.Dp(s.Y))
+	)
+	if s.Inset {
+		key.shift = offset
+	} else {
+		key.shift = image.Pt(key.blur, key.blur)
+		key.hole = key.shift.Sub(offset)
+	}
+	return key
+}
+
+// softShadowImage colors the coverage of the soft shadow of the key.
+func softShadowImage(key softShadowImageKey) paint.ImageOp {
+	var (
+		mask = softShadowMasks.get(key.mask, softShadowMask)
+		img  = image.NewNRGBA(mask.Rect)
+	)
+	for i, coverage := range mask.Pix {
+		pixel := img.Pix[4*i : 4*i+4]
+		pixel[0], pixel[1], pixel[2] = key.color.R, key.color.G, key.color.B
+		pixel[3] = uint8((int(coverage)*int(key.color.A) + 0x7F) / 0xFF)
+	}
+	return paint.NewImageOp(img)
+}
+
+// softShadowMask renders the rounded rectangle of the key, or everything around it
+// for inset shadows, and blurs it with three box blurs, which is close to a gaussian blur.
+// The rectangle is cut out of shadows that aren't inset, like cutout does for stroked ones.
+func softShadowMask(key softShadowKey) *image.Alpha {
+	var (
+		width   = key.size.X + 2*key.blur
+		height  = key.size.Y + 2*key.blur
+		half    = f32.Pt(float32(key.size.X)/2, float32(key.size.Y)/2)
+		outside float32
+	)
+	if key.inset {
+		width, height, outside = key.size.X, key.size.Y, 1
+	}
+	mask := make([]float32, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
 			var (
-				distance = float32(i+1) / float32(s.Layers)
-				width    = float32(spread) * float32(1-math.Exp(-1*math.Pow(float64(distance), s.Slope)))
-				alpha    = float32(s.Color.A) * distance
-				color    = color.NRGBA{
-					R: s.Color.R,
-					G: s.Color.G,
-					B: s.Color.B,
-					A: uint8(alpha * (1 - distance)),
-				}
+				p        = f32.Pt(float32(x-key.shift.X)+0.5, float32(y-key.shift.Y)+0.5).Sub(half)
+				coverage = roundedCoverage(p, half, key.radii)
 			)
-			shadowLayer(gtx.Ops, width, shape, color)
+			if key.inset {
+				coverage = 1 - coverage
+			}
+			mask[y*width+x] = coverage
 		}
-	}()
-	contentOp.Add(gtx.Ops)
-	return contentDimensions
+	}
+	if radius := key.blur / 3; radius > 0 {
+		line := make([]float32, width+height)
+		for pass := 0; pass < 3; pass++ {
+			for y := 0; y < height; y++ {
+				blurLine(mask, line, y*width, 1, width, radius, outside)
+			}
+			for x := 0; x < width; x++ {
+				blurLine(mask, line, x, width, height, radius, outside)
+			}
+		}
+	}
+	if !key.inset {
+		// The hole is half a pixel smaller than the rectangle to hide the seam between antialiased edges.
+		hole := half.Sub(f32.Pt(0.5, 0.5))
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				p := f32.Pt(float32(x-key.hole.X)+0.5, float32(y-key.hole.Y)+0.5).Sub(half)
+				mask[y*width+x] *= 1 - roundedCoverage(p, hole, key.radii)
+			}
+		}
+	}
+	img := image.NewAlpha(image.Rect(0, 0, width, height))
+	for i, coverage := range mask {
+		img.Pix[i] = uint8(coverage*0xFF + 0.5)
+	}
+	return img
+}
+
+// roundedCoverage returns how much of the pixel at the point is covered by the rounded
+// rectangle of the half size, both relative to the center of the rectangle.
+func roundedCoverage(p, half f32.Point, radii [4]int) float32 {
+	var radius float32
+	switch {
+	case p.X < 0 && p.Y < 0:
+		radius = float32(radii[0])
+	case p.Y < 0:
+		radius = float32(radii[1])
+	case p.X >= 0:
+		radius = float32(radii[2])
+	default:
+		radius = float32(radii[3])
+	}
+	var (
+		qx       = float32(math.Abs(float64(p.X))) - half.X + radius
+		qy       = float32(math.Abs(float64(p.Y))) - half.Y + radius
+		outside  = float32(math.Hypot(math.Max(float64(qx), 0), math.Max(float64(qy), 0)))
+		inside   = float32(math.Min(math.Max(float64(qx), float64(qy)), 0))
+		distance = outside + inside - radius
+		coverage = 0.5 - distance
+	)
+	switch {
+	case coverage < 0:
+		return 0
+	case coverage > 1:
+		return 1
+	}
+	return coverage
+}
+
+// blurLine box-blurs count values at the stride from the offset, taking values
+// past the ends as the outside value. The line is used as a buffer.
+func blurLine(values, line []float32, offset, stride, count, radius int, outside float32) {
+	for i := 0; i < count; i++ {
+		line[i] = values[offset+i*stride]
+	}
+	at := func(i int) float32 {
+		if i < 0 || i >= count {
+			return outside
+		}
+		return line[i]
+	}
+	var (
+		sum   float32
+		scale = 1 / float32(2*radius+1)
+	)
+	for i := -radius; i < radius; i++ {
+		sum += at(i)
+	}
+	for i := 0; i < count; i++ {
+		sum += at(i + radius)
+		values[offset+i*stride] = sum * scale
+		sum -= at(i - radius)
+	}
+}
+
+// cutout returns the area around the rounded rectangle up to the margin, without the rectangle.
+//
+// The rectangle is traced against the direction of the area, which makes
+// it a hole by the non-zero winding rule of outlines. The hole is half a pixel
+// smaller than the rectangle to hide the seam between antialiased edges.
+func cutout(ops *op.Ops, shape clip.RRect, margin int) clip.PathSpec {
+	var (
+		outer = shape.Rect.Inset(-margin)
+		tl    = layout.FPt(shape.Rect.Min).Add(f32.Pt(0.5, 0.5)) // tl is the top left corner of the hole.
+		br    = layout.FPt(shape.Rect.Max).Sub(f32.Pt(0.5, 0.5)) // br is the bottom right corner of the hole.
+		path  clip.Path
+	)
+	path.Begin(ops)
+	path.MoveTo(layout.FPt(outer.Min))
+	path.LineTo(f32.Pt(float32(outer.Max.X), float32(outer.Min.Y)))
+	path.LineTo(layout.FPt(outer.Max))
+	path.LineTo(f32.Pt(float32(outer.Min.X), float32(outer.Max.Y)))
+	path.Close()
+	var (
+		nw = insetRadius(shape.NW)
+		ne = insetRadius(shape.NE)
+		se = insetRadius(shape.SE)
+		sw = insetRadius(shape.SW)
+		// corner rounds the corner c from a to b with a cubic approximation of a quarter circle.
+		corner = func(a, c, b f32.Point) {
+			const k = 0.55228475
+			path.CubeTo(a.Add(c.Sub(a).Mul(k)), b.Add(c.Sub(b).Mul(k)), b)
+		}
+	)
+	// Counter-clockwise from the top of the left side.
+	path.MoveTo(f32.Pt(tl.X, tl.Y+nw))
+	path.LineTo(f32.Pt(tl.X, br.Y-sw))
+	corner(f32.Pt(tl.X, br.Y-sw), f32.Pt(tl.X, br.Y), f32.Pt(tl.X+sw, br.Y))
+	path.LineTo(f32.Pt(br.X-se, br.Y))
+	corner(f32.Pt(br.X-se, br.Y), br, f32.Pt(br.X, br.Y-se))
+	path.LineTo(f32.Pt(br.X, tl.Y+ne))
+	corner(f32.Pt(br.X, tl.Y+ne), f32.Pt(br.X, tl.Y), f32.Pt(br.X-ne, tl.Y))
+	path.LineTo(f32.Pt(tl.X+nw, tl.Y))
+	corner(f32.Pt(tl.X+nw, tl.Y), tl, f32.Pt(tl.X, tl.Y+nw))
+	path.Close()
+	return path.End()
+}
+
+// insetRadius returns the radius of a corner of the hole of cutout.
+func insetRadius(radius int) float32 {
+	return float32(math.Max(float64(radius)-0.5, 0))
 }
 
 // shadowLayer draws a shadow layer into the ops.
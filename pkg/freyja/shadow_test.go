@@ -0,0 +1,108 @@
+package freyja_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"github.com/widetape/freyja/pkg/freyja"
+)
+
+func ExampleShadows() {
+	gtx := layout.Context{Ops: new(op.Ops)}
+
+	card := freyja.Shadows{
+		// A sharp key shadow right below the card...
+		{Color: color.NRGBA{A: 0x30}, Spread: unit.Dp(2), Y: unit.Dp(1), Soft: true},
+		// ...and a wide ambient one around it.
+		{Color: color.NRGBA{A: 0x18}, Spread: unit.Dp(12), Y: unit.Dp(4), Soft: true},
+	}
+	shape := clip.UniformRRect(image.Rect(0, 0, 320, 180), 16)
+	card.LayoutRRect(
+		gtx,
+		shape,
+		func(gtx layout.Context) layout.Dimensions {
+			paint.FillShape(gtx.Ops, color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}, shape.Op(gtx.Ops))
+			return layout.Dimensions{Size: shape.Rect.Size()}
+		},
+	)
+}
+
+func TestShadow_StrokedCache(t *testing.T) {
+	var (
+		shadow = freyja.Shadow{Color: color.NRGBA{A: 0x40}, Layers: 4, Spread: unit.Dp(8), Slope: 1}
+		shape  = clip.UniformRRect(image.Rect(0, 0, 320, 180), 16)
+		metric = unit.Metric{PxPerDp: 1, PxPerSp: 1}
+		cached = freyja.StrokedShadow(shadow, shape, metric)
+	)
+	if freyja.StrokedShadow(shadow, shape, metric) != cached {
+		t.Error("shadow of the same rectangle is recorded again")
+	}
+	if freyja.StrokedShadow(shadow, clip.UniformRRect(shape.Rect, 8), metric) == cached {
+		t.Error("shadow of a rectangle with other corners is reused")
+	}
+	if freyja.StrokedShadow(shadow, shape, unit.Metric{PxPerDp: 2, PxPerSp: 2}) == cached {
+		t.Error("shadow in another metric is reused")
+	}
+}
+
+func TestShadow_SoftInterior(t *testing.T) {
+	var (
+		shadow     = freyja.Shadow{Color: color.NRGBA{A: 0xFF}, Spread: unit.Dp(12), Y: unit.Dp(4), Soft: true}
+		shape      = clip.UniformRRect(image.Rect(0, 0, 100, 60), 8)
+		mask, hole = freyja.SoftShadowMask(shadow, shape)
+		below      = image.Pt(50, 62).Add(hole)
+	)
+	// The inside of the shape without its rounded corners.
+	for _, interior := range []image.Rectangle{image.Rect(8, 1, 92, 59), image.Rect(1, 8, 99, 52)} {
+		for y := interior.Min.Y; y < interior.Max.Y; y++ {
+			for x := interior.Min.X; x < interior.Max.X; x++ {
+				if a := mask.AlphaAt(x+hole.X, y+hole.Y).A; a != 0 {
+					t.Fatalf("shadow covers (%d, %d) inside the shape with alpha %d", x, y, a)
+				}
+			}
+		}
+	}
+	if a := mask.AlphaAt(below.X, below.Y).A; a == 0 {
+		t.Error("shadow doesn't cover the area below the shape")
+	}
+}
+
+func BenchmarkShadow_LayoutRRect(b *testing.B) {
+	for _, soft := range []bool{false, true} {
+		name := "Stroked"
+		if soft {
+			name = "Soft"
+		}
+		b.Run(name, func(b *testing.B) {
+			shadow := freyja.Shadow{
+				Color:  color.NRGBA{A: 0x40},
+				Layers: 8,
+				Spread: unit.Dp(12),
+				Y:      unit.Dp(2),
+				Slope:  1.5,
+				Soft:   soft,
+			}
+			var (
+				ops   = new(op.Ops)
+				gtx   = layout.Context{Metric: unit.Metric{PxPerDp: 2, PxPerSp: 2}, Ops: ops}
+				shape = clip.UniformRRect(image.Rect(0, 0, 320, 180), 16)
+			)
+			for i := 0; i < b.N; i++ {
+				ops.Reset()
+				shadow.LayoutRRect(
+					gtx,
+					shape,
+					func(gtx layout.Context) layout.Dimensions {
+						return layout.Dimensions{Size: shape.Rect.Size()}
+					},
+				)
+			}
+		})
+	}
+}
@@ -3,97 +3,508 @@ package freyja
 import (
 	"image"
 	"image/color"
+	"math"
+	"strconv"
+	"time"
 
+	"gioui.org/font"
+	"gioui.org/io/key"
+	"gioui.org/io/semantic"
 	"gioui.org/layout"
 	"gioui.org/op"
 	"gioui.org/op/clip"
 	"gioui.org/op/paint"
+	"gioui.org/text"
 	"gioui.org/unit"
 	"gioui.org/widget"
+	"github.com/widetape/freyja/pkg/freyja/anim"
 )
 
+// Slider is a control for selecting a value in a range by dragging a knob.
 type Slider struct {
-	Origin widget.Float
+	Origin   widget.Float // Origin is the float of this slider, its Changed reports only changes by dragging.
+	Disabled bool         // Disabled shows the slider disabled and makes it ignore input other than hovering.
 
-	Background         color.NRGBA
-	BackgroundDisabled color.NRGBA
-	BackgroundWidth    unit.Dp
+	Min    float32 // Min is the value at the start of the track, the range is 0 to 1 if Min equals Max.
+	Max    float32 // Max is the value at the end of the track.
+	Step   float32 // Step snaps the value to multiples of Step from Min, the value is continuous if zero.
+	Invert bool    // Invert makes the value grow from right to left, or from top to bottom in vertical mode.
 
-	Knob         color.NRGBA
-	KnobDisabled color.NRGBA
-	KnobSize     unit.Dp
-	KnobShadow   Shadow
+	Axis layout.Axis // Axis is the direction of the track, the value grows from bottom to top if it's vertical.
 
-	Tint color.NRGBA
+	Background         color.NRGBA // Background is the color of the track.
+	BackgroundDisabled color.NRGBA // BackgroundDisabled is used instead of Background in disabled mode.
+	BackgroundWidth    unit.Dp     // BackgroundWidth is the thickness of the track.
+
+	Knob         color.NRGBA // Knob is the color of the knob.
+	KnobDisabled color.NRGBA // KnobDisabled is used instead of Knob in disabled mode.
+	KnobSize     unit.Dp     // KnobSize is the diameter of the knob.
+	KnobShadow   Shadows     // KnobShadow is the shadow casted by the knob.
+
+	Tint         color.NRGBA // Tint is the color of the active part of the track, between the tint origin and the knob.
+	TintDisabled color.NRGBA // TintDisabled is used instead of Tint in disabled mode.
+	TintOrigin   float32     // TintOrigin is where the tint starts as a fraction of the range from Min, e.g. 0.5 for balance controls.
+
+	FocusRing FocusRing // FocusRing is drawn around the knob while the slider is focused.
+
+	Ticks             float32     // Ticks is the interval between tick marks under the track, there are no ticks if zero.
+	TickColor         color.NRGBA // TickColor is the color of tick marks and their labels.
+	TickColorDisabled color.NRGBA // TickColorDisabled is used instead of TickColor in disabled mode.
+	TickLength        unit.Dp     // TickLength is the length of tick marks.
+
+	Shaper   *text.Shaper // Shaper is used to layout the labels of tick marks, there are no labels if nil.
+	Font     font.Font    // Font is used for the labels.
+	FontSize unit.Sp      // FontSize is the size of the labels.
+
+	KeyStep  float32                    // KeyStep is the change of the value by arrow keys, Step or a hundredth of the range if zero.
+	PageStep float32                    // PageStep is the change of the value by PageUp and PageDown, a tenth of the range if zero.
+	Format   func(value float32) string // Format describes the value to screen readers, labels tick marks and the bubble, %g if nil.
+
+	Bubble SliderBubble // Bubble shows the value next to the knob while it's dragged.
+
+	keyTag  struct{}
+	focused bool
+	changed bool
+	bubble  anim.Tween[float32]
+
+	hoverable
+
+	themed
 }
 
+// SliderBubble is the label showing the value of a slider next to its knob:
+// above the knob of horizontal sliders and to the left of vertical ones.
+//
+// The bubble uses the shaper and the font of the slider. There is no bubble
+// if its Background is transparent or the slider has no Shaper.
+type SliderBubble struct {
+	Background   color.NRGBA   // Background is the color of the bubble.
+	Foreground   color.NRGBA   // Foreground is the color of the text.
+	CornerRadius unit.Dp       // CornerRadius is the radius of smooth corners.
+	Inset        layout.Inset  // Inset is used to margin the text from the borders of the bubble.
+	Gap          unit.Dp       // Gap is the distance between the knob and the bubble.
+	Shadow       Shadows       // Shadow is the shadow casted by the bubble.
+	FadeDuration time.Duration // FadeDuration is how long the bubble takes to appear and to fade out after release.
+}
+
+// sliderKeys are the keys that move the knobs of sliders.
+const sliderKeys = "[←,→,↑,↓,⇞,⇟,⇱,⇲]"
+
+// Layout lays the slider out to the context.
 func (s *Slider) Layout(gtx layout.Context) layout.Dimensions {
-	return layout.Inset{Left: s.KnobSize / 2, Right: s.KnobSize / 2}.Layout(
+	if s.stale() {
+		s.theme.paintSlider(s)
+	}
+	s.trackHover(gtx)
+	gtx, disabled := disable(gtx, s.Disabled)
+	s.update(gtx, disabled)
+	return s.inset().Layout(
 		gtx,
 		func(gtx layout.Context) layout.Dimensions {
 			var (
-				disabled = gtx.Queue == nil
 				knobSize = gtx.Dp(s.KnobSize)
-				size     = image.Pt(gtx.Constraints.Max.X, knobSize)
+				length   = s.Axis.Convert(gtx.Constraints.Max).X
+				size     = s.Axis.Convert(image.Pt(length, knobSize))
+				min, max = s.bounds()
 			)
+			s.track(gtx, length, disabled)
 			func() {
 				var (
-					width  = gtx.Dp(s.BackgroundWidth)
-					radius = width / 2
-					shape  = clip.UniformRRect(
-						image.Rectangle{
-							Max: image.Pt(
-								size.X,
-								width,
-							),
-						},
-						radius,
-					)
-					color color.NRGBA
+					fgtx  = gtx
+					value = s.snap(s.Origin.Value)
 				)
-				if disabled {
-					color = s.BackgroundDisabled
-				} else {
-					color = s.Background
-				}
-				defer op.Offset(image.Pt(0, (knobSize/2)-(width/2))).Push(gtx.Ops).Pop()
-				paint.FillShape(gtx.Ops, color, shape.Op(gtx.Ops))
-			}()
-			func() {
-				var fgtx = gtx
-				fgtx.Constraints.Min = image.Pt(size.X, size.Y)
+				fgtx.Constraints.Min = size
 				s.Origin.Layout(
 					fgtx,
-					layout.Horizontal,
-					0, 1, false,
+					s.Axis,
+					min, max, s.Invert,
 					knobSize,
 				)
-			}()
-			func() {
-				var (
-					shape = clip.Ellipse{
-						Max: image.Pt(knobSize, knobSize),
-					}
-					color color.NRGBA
-				)
-				if disabled {
-					color = s.KnobDisabled
-				} else {
-					color = s.Knob
+				s.Origin.Value = s.snap(s.Origin.Value)
+				// The changed flag of Origin is left to the caller, so drags are
+				// told apart by the value they moved the knob from.
+				if s.Origin.Value != value {
+					s.changed = true
 				}
-				defer op.Offset(image.Pt(int(s.Origin.Pos()-float32(knobSize/2)), 0)).Push(gtx.Ops).Pop()
-				s.KnobShadow.Layout(
-					gtx,
-					shape.Path(gtx.Ops),
-					func(gtx layout.Context) layout.Dimensions {
-						paint.FillShape(gtx.Ops, color, shape.Op(gtx.Ops))
-						return layout.Dimensions{}
-					},
-				)
 			}()
+			s.focus(gtx, size, disabled)
+			if s.Ticks > 0 {
+				size = size.Add(s.Axis.Convert(image.Pt(0, s.ticks(gtx, length, knobSize, disabled))))
+			}
+			from, to := s.tintSpan(s.Origin.Value, length)
+			s.tint(gtx, from, to, disabled)
+			s.knob(gtx, s.position(s.Origin.Value, length), s.focused, disabled)
+			s.showBubble(gtx, s.Origin.Value, s.position(s.Origin.Value, length), s.Origin.Dragging() && !disabled)
+			s.addHoverArea(gtx.Ops, size)
 			return layout.Dimensions{
 				Size: size,
 			}
 		},
 	)
 }
+
+// inset makes room for the knob at the ends of the track.
+func (s *Slider) inset() layout.Inset {
+	if s.Axis == layout.Vertical {
+		return layout.Inset{Top: s.KnobSize / 2, Bottom: s.KnobSize / 2}
+	}
+	return layout.Inset{Left: s.KnobSize / 2, Right: s.KnobSize / 2}
+}
+
+// track draws the track of the length.
+func (s *Slider) track(gtx layout.Context, length int, disabled bool) {
+	var (
+		knobSize = gtx.Dp(s.KnobSize)
+		width    = gtx.Dp(s.BackgroundWidth)
+		radius   = width / 2
+		shape    = clip.UniformRRect(
+			image.Rectangle{
+				Max: s.Axis.Convert(
+					image.Pt(
+						length,
+						width,
+					),
+				),
+			},
+			radius,
+		)
+		color color.NRGBA
+	)
+	if disabled {
+		color = s.BackgroundDisabled
+	} else {
+		color = s.Background
+	}
+	defer op.Offset(s.Axis.Convert(image.Pt(0, (knobSize/2)-(width/2)))).Push(gtx.Ops).Pop()
+	paint.FillShape(gtx.Ops, color, shape.Op(gtx.Ops))
+}
+
+// knob draws a knob centered at the position along the track.
+func (s *Slider) knob(gtx layout.Context, position int, focused, disabled bool) {
+	var (
+		knobSize = gtx.Dp(s.KnobSize)
+		shape    = clip.Ellipse{
+			Max: image.Pt(knobSize, knobSize),
+		}
+		color color.NRGBA
+	)
+	if disabled {
+		color = s.KnobDisabled
+	} else {
+		color = s.Knob
+	}
+	defer op.Offset(s.Axis.Convert(image.Pt(position-knobSize/2, 0))).Push(gtx.Ops).Pop()
+	s.KnobShadow.LayoutRRect(
+		gtx,
+		clip.UniformRRect(image.Rectangle{Max: image.Pt(knobSize, knobSize)}, knobSize/2),
+		func(gtx layout.Context) layout.Dimensions {
+			paint.FillShape(gtx.Ops, color, shape.Op(gtx.Ops))
+			return layout.Dimensions{}
+		},
+	)
+	if focused {
+		s.FocusRing.draw(gtx, image.Rectangle{Max: image.Pt(knobSize, knobSize)}, knobSize/2)
+	}
+}
+
+// tint draws the tint over the part of the track between the positions.
+func (s *Slider) tint(gtx layout.Context, from, to int, disabled bool) {
+	if from > to {
+		from, to = to, from
+	}
+	var (
+		knobSize = gtx.Dp(s.KnobSize)
+		width    = gtx.Dp(s.BackgroundWidth)
+		shape    = clip.UniformRRect(
+			image.Rectangle{
+				Min: s.Axis.Convert(image.Pt(from, 0)),
+				Max: s.Axis.Convert(image.Pt(to, width)),
+			},
+			width/2,
+		)
+		color = s.Tint
+	)
+	if disabled {
+		color = s.TintDisabled
+	}
+	defer op.Offset(s.Axis.Convert(image.Pt(0, (knobSize/2)-(width/2)))).Push(gtx.Ops).Pop()
+	paint.FillShape(gtx.Ops, color, shape.Op(gtx.Ops))
+}
+
+// tintSpan returns the positions along the track of the length
+// between which the tint is drawn for the value.
+func (s *Slider) tintSpan(value float32, length int) (from, to int) {
+	min, max := s.bounds()
+	return s.position(min+(max-min)*s.TintOrigin, length), s.position(value, length)
+}
+
+// showBubble fades the bubble in or out and draws it for the value
+// of the knob at the position, on top of other widgets.
+func (s *Slider) showBubble(gtx layout.Context, value float32, position int, shown bool) {
+	var target float32
+	if shown {
+		target = 1
+	}
+	s.bubble.Duration = s.Bubble.FadeDuration
+	opacity := s.bubble.Animate(gtx, target)
+	if opacity <= 0 || s.Bubble.Background.A == 0 || s.Shaper == nil {
+		return
+	}
+	var (
+		bubble      = &s.Bubble
+		colorRecord = op.Record(gtx.Ops)
+	)
+	paint.ColorOp{Color: fade(bubble.Foreground, opacity)}.Add(gtx.Ops)
+	textColor := colorRecord.Stop()
+	contentRecord := op.Record(gtx.Ops)
+	cgtx := gtx
+	cgtx.Constraints.Min = image.Point{}
+	dimensions := bubble.Inset.Layout(
+		cgtx,
+		func(gtx layout.Context) layout.Dimensions {
+			return widget.Label{MaxLines: 1}.Layout(
+				gtx,
+				s.Shaper,
+				s.Font,
+				s.FontSize,
+				s.describe(value),
+				textColor,
+			)
+		},
+	)
+	content := contentRecord.Stop()
+	var (
+		size   = dimensions.Size
+		gap    = gtx.Dp(bubble.Gap)
+		offset = image.Pt(position-size.X/2, -gap-size.Y)
+		shape  = clip.UniformRRect(image.Rectangle{Max: size}, gtx.Dp(bubble.CornerRadius))
+		shadow = bubble.Shadow.fade(opacity)
+	)
+	if s.Axis == layout.Vertical {
+		offset = image.Pt(-gap-size.X, position-size.Y/2)
+	}
+	record := op.Record(gtx.Ops)
+	func() {
+		defer op.Offset(offset).Push(gtx.Ops).Pop()
+		shadow.LayoutRRect(
+			gtx,
+			shape,
+			func(gtx layout.Context) layout.Dimensions {
+				paint.FillShape(gtx.Ops, fade(bubble.Background, opacity), shape.Op(gtx.Ops))
+				return layout.Dimensions{Size: size}
+			},
+		)
+		content.Add(gtx.Ops)
+	}()
+	op.Defer(gtx.Ops, record.Stop())
+}
+
+// ticks draws tick marks and their labels next to the track of the length
+// starting at the offset across the track, and returns the space they take
+// across the track.
+func (s *Slider) ticks(gtx layout.Context, length, offset int, disabled bool) int {
+	var (
+		min, max = s.bounds()
+		size     = gtx.Dp(s.TickLength)
+		width    = gtx.Dp(1)
+		extent   = size
+		color    = s.TickColor
+	)
+	if disabled {
+		color = s.TickColorDisabled
+	}
+	colorRecord := op.Record(gtx.Ops)
+	paint.ColorOp{Color: color}.Add(gtx.Ops)
+	labelColor := colorRecord.Stop()
+	// Count ticks instead of summing intervals to not accumulate errors.
+	for i := 0; ; i++ {
+		value := min + float32(i)*s.Ticks
+		if value > max+s.Ticks/1000 {
+			break
+		}
+		position := s.position(value, length)
+		func() {
+			defer op.Offset(s.Axis.Convert(image.Pt(position-width/2, offset))).Push(gtx.Ops).Pop()
+			paint.FillShape(gtx.Ops, color, clip.Rect{Max: s.Axis.Convert(image.Pt(width, size))}.Op())
+		}()
+		if s.Shaper == nil {
+			continue
+		}
+		func() {
+			var (
+				lgtx   = gtx
+				record = op.Record(gtx.Ops)
+			)
+			lgtx.Constraints.Min = image.Point{}
+			dimensions := widget.Label{MaxLines: 1}.Layout(
+				lgtx,
+				s.Shaper,
+				s.Font,
+				s.FontSize,
+				s.describe(value),
+				labelColor,
+			)
+			var (
+				label = record.Stop()
+				// labelSize is the size of the label along and across the track.
+				labelSize = s.Axis.Convert(dimensions.Size)
+			)
+			if e := size + labelSize.Y; e > extent {
+				extent = e
+			}
+			defer op.Offset(s.Axis.Convert(image.Pt(position-labelSize.X/2, offset+size))).Push(gtx.Ops).Pop()
+			label.Add(gtx.Ops)
+		}()
+	}
+	return extent
+}
+
+// bounds returns the range of the slider.
+func (s *Slider) bounds() (min, max float32) {
+	if s.Min == s.Max {
+		return 0, 1
+	}
+	return s.Min, s.Max
+}
+
+// snap rounds the value to the nearest step and clamps it into the range.
+func (s *Slider) snap(value float32) float32 {
+	min, max := s.bounds()
+	if s.Step > 0 {
+		value = min + float32(math.Round(float64((value-min)/s.Step)))*s.Step
+	}
+	if min > max {
+		min, max = max, min
+	}
+	if value < min {
+		value = min
+	} else if value > max {
+		value = max
+	}
+	return value
+}
+
+// position returns the offset of the value from the top left end of the track of the length.
+func (s *Slider) position(value float32, length int) int {
+	min, max := s.bounds()
+	fraction := (value - min) / (max - min)
+	if s.Invert != (s.Axis == layout.Vertical) {
+		fraction = 1 - fraction
+	}
+	return int(fraction*float32(length) + 0.5)
+}
+
+// value returns the value at the offset from the top left end of the track of the length.
+func (s *Slider) value(position float32, length int) float32 {
+	min, max := s.bounds()
+	fraction := position / float32(length)
+	if s.Invert != (s.Axis == layout.Vertical) {
+		fraction = 1 - fraction
+	}
+	return min + (max-min)*fraction
+}
+
+// Focused reports whether the slider has keyboard focus.
+func (s *Slider) Focused() bool {
+	return s.focused
+}
+
+// Changed reports whether the value has changed by dragging
+// or by keyboard since the last call to Changed.
+func (s *Slider) Changed() bool {
+	changed := s.changed
+	s.changed = false
+	return changed
+}
+
+// update handles the focus and the keys of the slider.
+func (s *Slider) update(gtx layout.Context, disabled bool) {
+	for _, e := range gtx.Events(&s.keyTag) {
+		switch e := e.(type) {
+		case key.FocusEvent:
+			s.focused = e.Focus
+		case key.Event:
+			if value := s.press(e, s.Origin.Value); value != s.Origin.Value {
+				s.Origin.Value = value
+				s.changed = true
+			}
+		}
+	}
+	if disabled {
+		s.focused = false
+	}
+}
+
+// press returns the value moved by the key event.
+func (s *Slider) press(e key.Event, value float32) float32 {
+	if e.State != key.Press {
+		return value
+	}
+	var (
+		min, max = s.bounds()
+		keyStep  = s.KeyStep
+		pageStep = s.PageStep
+	)
+	if keyStep <= 0 {
+		if s.Step > 0 {
+			keyStep = s.Step
+		} else {
+			keyStep = (max - min) / 100
+		}
+	}
+	if pageStep <= 0 {
+		pageStep = (max - min) / 10
+	}
+	switch e.Name {
+	case key.NameRightArrow, key.NameUpArrow, key.NameLeftArrow, key.NameDownArrow:
+		value += s.arrow(e.Name) * keyStep
+	case key.NamePageUp:
+		value += pageStep
+	case key.NamePageDown:
+		value -= pageStep
+	case key.NameHome:
+		value = min
+	case key.NameEnd:
+		value = max
+	}
+	return s.snap(value)
+}
+
+// arrow returns the direction in which the arrow key of the name moves the value,
+// so that the knob follows the arrows pointing along the track.
+func (s *Slider) arrow(name string) float32 {
+	var (
+		direction float32 = 1
+		along             = (name == key.NameLeftArrow || name == key.NameRightArrow) == (s.Axis == layout.Horizontal)
+	)
+	if name == key.NameLeftArrow || name == key.NameDownArrow {
+		direction = -1
+	}
+	if s.Invert && along {
+		direction = -direction
+	}
+	return direction
+}
+
+// describe formats the value for screen readers and labels.
+func (s *Slider) describe(value float32) string {
+	if s.Format != nil {
+		return s.Format(value)
+	}
+	return strconv.FormatFloat(float64(value), 'g', -1, 32)
+}
+
+// focus describes the value to screen readers, makes the slider focusable
+// over its area and takes the focus when the knob is dragged.
+func (s *Slider) focus(gtx layout.Context, size image.Point, disabled bool) {
+	defer clip.Rect{Max: size}.Push(gtx.Ops).Pop()
+	semantic.DescriptionOp(s.describe(s.Origin.Value)).Add(gtx.Ops)
+	semantic.DisabledOp(disabled).Add(gtx.Ops)
+	if disabled {
+		return
+	}
+	key.InputOp{Tag: &s.keyTag, Keys: sliderKeys}.Add(gtx.Ops)
+	if s.Origin.Dragging() && !s.focused {
+		key.FocusOp{Tag: &s.keyTag}.Add(gtx.Ops)
+	}
+}
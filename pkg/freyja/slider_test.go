@@ -0,0 +1,157 @@
+package freyja_test
+
+import (
+	"image"
+	"testing"
+
+	"gioui.org/f32"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/io/router"
+	"gioui.org/layout"
+	"github.com/widetape/freyja/pkg/freyja"
+)
+
+func TestSlider_Keys(t *testing.T) {
+	slider := freyja.Slider{KnobSize: 20, KeyStep: 0.25}
+	h := newHarness(layout.Exact(image.Pt(200, 20)), slider.Layout)
+	h.frame()
+	h.queue.MoveFocus(router.FocusForward)
+	h.frame()
+	if !slider.Focused() {
+		t.Fatal("slider is not focused")
+	}
+	steps := []struct {
+		key   string
+		value float32
+	}{
+		{key.NameRightArrow, 0.25},
+		{key.NameUpArrow, 0.5},
+		{key.NameLeftArrow, 0.25},
+		{key.NameEnd, 1},
+		{key.NamePageDown, 0.9},
+		{key.NameHome, 0},
+		{key.NameDownArrow, 0},
+	}
+	for _, step := range steps {
+		h.press(step.key)
+		if slider.Origin.Value != step.value {
+			t.Errorf("after %s: got value %v, want %v", step.key, slider.Origin.Value, step.value)
+		}
+	}
+	if !slider.Changed() {
+		t.Error("keys did not change the slider")
+	}
+}
+
+func TestSlider_Step(t *testing.T) {
+	slider := freyja.Slider{KnobSize: 20, Min: 0, Max: 100, Step: 5}
+	h := newHarness(layout.Exact(image.Pt(200, 20)), slider.Layout)
+	slider.Origin.Value = 42
+	h.frame()
+	if slider.Origin.Value != 40 {
+		t.Fatalf("got value %v, want it snapped to 40", slider.Origin.Value)
+	}
+	h.queue.MoveFocus(router.FocusForward)
+	h.frame()
+	h.press(key.NameRightArrow)
+	h.press(key.NamePageUp)
+	if slider.Origin.Value != 55 {
+		t.Fatalf("got value %v, want 55", slider.Origin.Value)
+	}
+}
+
+func TestSlider_Vertical(t *testing.T) {
+	slider := freyja.Slider{KnobSize: 20, Axis: layout.Vertical}
+	h := newHarness(layout.Constraints{Max: image.Pt(300, 220)}, slider.Layout)
+	if dimensions, want := h.frame(), image.Pt(20, 220); dimensions.Size != want {
+		t.Fatalf("got size %v, want %v", dimensions.Size, want)
+	}
+	// Press at the top of the track, which is the maximum.
+	h.click(f32.Pt(10, 10))
+	if slider.Origin.Value != 1 {
+		t.Fatalf("got value %v, want 1", slider.Origin.Value)
+	}
+}
+
+func TestSlider_Description(t *testing.T) {
+	slider := freyja.Slider{KnobSize: 20, Min: 0, Max: 100}
+	slider.Origin.Value = 30
+	h := newHarness(layout.Exact(image.Pt(200, 20)), slider.Layout)
+	h.frame()
+	for _, node := range h.queue.AppendSemantics(nil) {
+		if node.Desc.Description == "30" {
+			if want := image.Rect(10, 0, 190, 20); node.Desc.Bounds != want {
+				t.Errorf("described area is %v, want the track %v", node.Desc.Bounds, want)
+			}
+			return
+		}
+	}
+	t.Error("value is not described to screen readers")
+}
+
+func TestSlider_OriginChanged(t *testing.T) {
+	slider := freyja.Slider{KnobSize: 20}
+	h := newHarness(layout.Exact(image.Pt(200, 20)), slider.Layout)
+	h.frame()
+	h.click(f32.Pt(150, 10))
+	if !slider.Origin.Changed() {
+		t.Error("dragging change is not left to Origin")
+	}
+	if !slider.Changed() {
+		t.Error("dragging change is not reported")
+	}
+}
+
+func TestSlider_TintSpan(t *testing.T) {
+	tests := []struct {
+		name     string
+		slider   freyja.Slider
+		value    float32
+		from, to int
+	}{
+		{"start", freyja.Slider{}, 0.3, 0, 30},
+		{"middle", freyja.Slider{TintOrigin: 0.5}, 0.2, 50, 20},
+		{"range", freyja.Slider{Min: -10, Max: 10, TintOrigin: 0.5}, 5, 50, 75},
+		{"inverted", freyja.Slider{Invert: true}, 0.3, 100, 70},
+		{"vertical", freyja.Slider{Axis: layout.Vertical}, 0.3, 100, 70},
+	}
+	for _, test := range tests {
+		if from, to := test.slider.TintSpan(test.value, 100); from != test.from || to != test.to {
+			t.Errorf("%s: got tint from %d to %d, want from %d to %d", test.name, from, to, test.from, test.to)
+		}
+	}
+}
+
+func TestSlider_Bubble(t *testing.T) {
+	theme := newTheme()
+	slider := theme.Slider()
+	slider.Min, slider.Max, slider.Step = 0, 100, 1
+	h := newHarness(layout.Exact(image.Pt(220, 20)), slider.Layout)
+	bubble := func() string {
+		for _, node := range h.queue.AppendSemantics(nil) {
+			if node.Desc.Label != "" {
+				return node.Desc.Label
+			}
+		}
+		return ""
+	}
+	h.frame()
+	if label := bubble(); label != "" {
+		t.Fatalf("bubble %q is shown before dragging", label)
+	}
+	h.queue.Queue(pointer.Event{Type: pointer.Press, Source: pointer.Mouse, Buttons: pointer.ButtonPrimary, Position: f32.Pt(60, 10)})
+	h.frame()
+	h.gtx.Now = h.gtx.Now.Add(slider.Bubble.FadeDuration / 2)
+	h.frame()
+	if label := bubble(); label != "25" {
+		t.Fatalf("got bubble %q while dragging, want %q", label, "25")
+	}
+	h.queue.Queue(pointer.Event{Type: pointer.Release, Source: pointer.Mouse, Position: f32.Pt(60, 10)})
+	h.frame()
+	h.gtx.Now = h.gtx.Now.Add(slider.Bubble.FadeDuration)
+	h.frame()
+	if label := bubble(); label != "" {
+		t.Errorf("bubble %q is shown after it faded out", label)
+	}
+}
@@ -0,0 +1,62 @@
+package freyja
+
+import (
+	"image/color"
+	"math"
+	"time"
+
+	"gioui.org/f32"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+)
+
+// Spinner is an indeterminate progress indicator: an open ring spinning around its center.
+type Spinner struct {
+	Width  unit.Dp       // Width is the width of the ring.
+	Period time.Duration // Period is how long the ring takes to make a turn.
+}
+
+// spinnerSweep is the angle the ring of spinners covers.
+const spinnerSweep = 1.5 * math.Pi
+
+// Layout draws the spinner in the color and fills the minimum constraints.
+// The spinner keeps requesting frames while it's laid out.
+//
+// The Layout method of a spinner is an Icon.
+func (s Spinner) Layout(gtx layout.Context, color color.NRGBA) layout.Dimensions {
+	var (
+		size     = gtx.Constraints.Min
+		diameter = size.X
+		width    = gtx.Dp(s.Width)
+	)
+	if size.Y < diameter {
+		diameter = size.Y
+	}
+	if diameter <= width || s.Period <= 0 {
+		return layout.Dimensions{Size: size}
+	}
+	turn := gtx.Now.UnixNano() % int64(s.Period)
+	if turn < 0 {
+		turn += int64(s.Period)
+	}
+	var (
+		radius = float32(diameter-width) / 2
+		center = f32.Pt(float32(size.X)/2, float32(size.Y)/2)
+		angle  = 2 * math.Pi * float64(turn) / float64(s.Period)
+		start  = center.Add(f32.Pt(radius*float32(math.Cos(angle)), radius*float32(math.Sin(angle))))
+		path   clip.Path
+	)
+	path.Begin(gtx.Ops)
+	path.MoveTo(start)
+	path.Arc(center.Sub(start), center.Sub(start), spinnerSweep)
+	var stroke = clip.Stroke{
+		Path:  path.End(),
+		Width: float32(width),
+	}
+	paint.FillShape(gtx.Ops, color, stroke.Op())
+	op.InvalidateOp{}.Add(gtx.Ops)
+	return layout.Dimensions{Size: size}
+}
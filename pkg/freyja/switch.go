@@ -2,26 +2,38 @@ package freyja
 
 import (
 	"image"
+	"image/color"
+	"time"
 
 	"gioui.org/io/semantic"
 	"gioui.org/layout"
 	"gioui.org/op"
 	"gioui.org/op/clip"
+	"gioui.org/op/paint"
 	"gioui.org/unit"
 	"gioui.org/widget"
+	"github.com/widetape/freyja/pkg/freyja/anim"
 )
 
 // Switch is a toggle button with knob.
 type Switch struct {
-	Origin widget.Bool // Origin is the bool if this switch.
+	Origin   widget.Bool // Origin is the bool if this switch.
+	Disabled bool        // Disabled shows the switch disabled and makes it ignore input other than hovering.
 
 	Background         op.CallOp // Background is used to draw the background for this switch.
 	BackgroundDisabled op.CallOp // BackgroundDisabled is used instead of Background when the switch is disabled.
 
-	EnvironmentShadow Shadow // EnvironmentShadow is the shadow casted into the switch.
-	KnobShadow        Shadow // KnobShadow is the shadow casted by the knob.
+	EnvironmentShadow Shadows // EnvironmentShadow is the shadow casted into the switch, it's drawn inset even if it isn't.
+	KnobShadow        Shadows // KnobShadow is the shadow casted by the knob.
 
-	Tint         op.CallOp // Tint is used to draw the tinted background overlay of the switch in "On" mode.
+	FocusRing FocusRing // FocusRing is drawn around the switch while it's focused.
+
+	Label ControlLabel // Label is the text next to the switch.
+
+	TintColor         color.NRGBA // TintColor fades in over the background while the switch turns "On".
+	TintColorDisabled color.NRGBA // TintColorDisabled is used instead of TintColor when the switch is disabled.
+
+	Tint         op.CallOp // Tint is drawn over the background past the middle of the slide if TintColor is transparent.
 	TintDisabled op.CallOp // TintDisabled is used instead of Tint when the switch is disabled.
 
 	Knob         op.CallOp // Knob is used to draw the knob of this switch.
@@ -30,81 +42,143 @@ type Switch struct {
 
 	Inset unit.Dp // Inset is the gab between the knob and the borders of switch.
 	Shift unit.Dp // Shift is the distance that the knob shifts to the right.
+
+	Duration time.Duration // Duration is how long the knob takes to slide after Origin.Value changes.
+	Easing   anim.Easing   // Easing is the curve of the slide, linear if nil.
+
+	progress anim.Tween[float32] // progress is the position of the knob from 0 (off) to 1 (on).
+
+	hoverable
+
+	themed
 }
 
 // Layout lays Switch out to the context.
 func (s *Switch) Layout(gtx layout.Context) layout.Dimensions {
-	disabled := gtx.Queue == nil
+	if s.stale() {
+		s.theme.paintSwitch(s)
+	}
+	s.trackHover(gtx)
+	gtx, disabled := disable(gtx, s.Disabled)
 	return s.Origin.Layout(
 		gtx,
 		func(gtx layout.Context) layout.Dimensions {
 			semantic.Switch.Add(gtx.Ops)
-			return layout.Stack{Alignment: layout.Center}.Layout(
+			semantic.DisabledOp(disabled).Add(gtx.Ops)
+			s.addHover(gtx.Ops)
+			return s.Label.layout(
 				gtx,
-				layout.Expanded(func(gtx layout.Context) layout.Dimensions {
-					var (
-						radius = gtx.Dp(s.KnobSize)/2 + gtx.Dp(s.Inset)
-						size   = gtx.Constraints.Min
-						shape  = clip.UniformRRect(image.Rectangle{Max: size}, radius)
-					)
-					func() {
-						defer shape.Push(gtx.Ops).Pop()
-						if disabled {
-							s.BackgroundDisabled.Add(gtx.Ops)
-							if s.Origin.Value {
-								s.TintDisabled.Add(gtx.Ops)
-							}
-						} else {
-							s.Background.Add(gtx.Ops)
-							if s.Origin.Value {
-								s.Tint.Add(gtx.Ops)
-							}
-						}
-						s.EnvironmentShadow.Layout(
-							gtx,
-							shape.Path(gtx.Ops),
-							func(gtx layout.Context) layout.Dimensions {
-								return layout.Dimensions{Size: size}
-							},
-						)
-					}()
-					return layout.Dimensions{Size: size}
-				}),
-				layout.Stacked(func(gtx layout.Context) layout.Dimensions {
-					inset := layout.Inset{
-						Top: s.Inset, Bottom: s.Inset,
-						Left: s.Inset, Right: s.Inset,
-					}
-					return inset.Layout(
+				disabled,
+				func(gtx layout.Context) layout.Dimensions {
+					progress := s.advance(gtx)
+					return layout.Stack{Alignment: layout.Center}.Layout(
 						gtx,
-						func(gtx layout.Context) layout.Dimensions {
+						layout.Expanded(func(gtx layout.Context) layout.Dimensions {
 							var (
-								size      = gtx.Dp(s.KnobSize)
-								shiftSize = gtx.Dp(s.Shift)
-								shift     image.Point
-								shape     = clip.Ellipse{Max: image.Pt(size, size)}
+								radius = gtx.Dp(s.KnobSize)/2 + gtx.Dp(s.Inset)
+								size   = gtx.Constraints.Min
+								shape  = clip.UniformRRect(image.Rectangle{Max: size}, radius)
 							)
-							if s.Origin.Value {
-								shift.X = shiftSize
+							func() {
+								defer shape.Push(gtx.Ops).Pop()
+								if disabled {
+									s.BackgroundDisabled.Add(gtx.Ops)
+								} else {
+									s.Background.Add(gtx.Ops)
+								}
+								s.tint(gtx, progress, disabled)
+								s.environmentShadow().LayoutRRect(
+									gtx,
+									shape,
+									func(gtx layout.Context) layout.Dimensions {
+										return layout.Dimensions{Size: size}
+									},
+								)
+							}()
+							if s.Origin.Focused() && !disabled {
+								s.FocusRing.draw(gtx, image.Rectangle{Max: size}, radius)
+							}
+							return layout.Dimensions{Size: size}
+						}),
+						layout.Stacked(func(gtx layout.Context) layout.Dimensions {
+							inset := layout.Inset{
+								Top: s.Inset, Bottom: s.Inset,
+								Left: s.Inset, Right: s.Inset,
 							}
-							defer op.Offset(shift).Push(gtx.Ops).Pop()
-							return s.KnobShadow.Layout(
+							return inset.Layout(
 								gtx,
-								shape.Path(gtx.Ops),
 								func(gtx layout.Context) layout.Dimensions {
-									defer shape.Push(gtx.Ops).Pop()
-									if disabled {
-										s.KnobDisabled.Add(gtx.Ops)
-									} else {
-										s.Knob.Add(gtx.Ops)
-									}
-									return layout.Dimensions{Size: image.Pt(size+shiftSize, size)}
+									var (
+										size      = gtx.Dp(s.KnobSize)
+										shiftSize = gtx.Dp(s.Shift)
+										shift     = image.Pt(int(progress*float32(shiftSize)), 0)
+										shape     = clip.Ellipse{Max: image.Pt(size, size)}
+									)
+									defer op.Offset(shift).Push(gtx.Ops).Pop()
+									return s.KnobShadow.LayoutRRect(
+										gtx,
+										clip.UniformRRect(image.Rectangle{Max: image.Pt(size, size)}, size/2),
+										func(gtx layout.Context) layout.Dimensions {
+											defer shape.Push(gtx.Ops).Pop()
+											if disabled {
+												s.KnobDisabled.Add(gtx.Ops)
+											} else {
+												s.Knob.Add(gtx.Ops)
+											}
+											return layout.Dimensions{Size: image.Pt(size+shiftSize, size)}
+										},
+									)
 								},
 							)
-						},
+						}),
 					)
-				}),
+				},
 			)
 		},
 	)
 }
+
+// environmentShadow returns EnvironmentShadow with every shadow inset. The track clips
+// the shadows, so an outer shadow around it, which is cut out of it, would not show.
+func (s *Switch) environmentShadow() Shadows {
+	inset := true
+	for _, shadow := range s.EnvironmentShadow {
+		inset = inset && shadow.Inset
+	}
+	if inset {
+		return s.EnvironmentShadow
+	}
+	shadows := make(Shadows, len(s.EnvironmentShadow))
+	for i, shadow := range s.EnvironmentShadow {
+		shadow.Inset = true
+		shadows[i] = shadow
+	}
+	return shadows
+}
+
+// tint cross-fades the background of the track into the tint as the knob slides.
+func (s *Switch) tint(gtx layout.Context, progress float32, disabled bool) {
+	color, tint := s.TintColor, s.Tint
+	if disabled {
+		color, tint = s.TintColorDisabled, s.TintDisabled
+	}
+	switch {
+	case color.A > 0 && progress > 0:
+		paint.ColorOp{Color: fade(color, progress)}.Add(gtx.Ops)
+		paint.PaintOp{}.Add(gtx.Ops)
+	case progress >= 0.5:
+		// A material can't be faded, so it's swapped in the middle of the slide.
+		tint.Add(gtx.Ops)
+	}
+}
+
+// advance moves the knob towards Origin.Value and returns its progress.
+func (s *Switch) advance(gtx layout.Context) float32 {
+	var target float32
+	if s.Origin.Value {
+		target = 1
+	}
+	s.progress.Duration = s.Duration
+	s.progress.Easing = s.Easing
+	return s.progress.Animate(gtx, target)
+}
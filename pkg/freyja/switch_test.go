@@ -0,0 +1,47 @@
+package freyja_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"gioui.org/f32"
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"github.com/widetape/freyja/pkg/freyja"
+)
+
+func TestSwitch_Label(t *testing.T) {
+	theme := newTheme()
+	toggle := theme.LabeledSwitch("Wi-Fi")
+	h := newHarness(layout.Constraints{Max: image.Pt(400, 100)}, toggle.Layout)
+	dimensions := h.frame()
+	// Click on the label, to the right of the switch.
+	h.click(f32.Pt(float32(dimensions.Size.X-5), float32(dimensions.Size.Y/2)))
+	if !toggle.Origin.Value {
+		t.Error("switch is not toggled by clicking its label")
+	}
+}
+
+func TestSwitch_EnvironmentShadow(t *testing.T) {
+	// A switch built by hand with an outer environment shadow, clipped into the track.
+	outer := freyja.Shadow{Color: color.NRGBA{A: 0x40}, Layers: 4, Spread: unit.Dp(4), Slope: 1}
+	toggle := freyja.Switch{
+		EnvironmentShadow: freyja.Shadows{outer},
+		KnobSize:          unit.Dp(20),
+		Inset:             unit.Dp(2),
+		Shift:             unit.Dp(20),
+	}
+	h := newHarness(layout.Constraints{Max: image.Pt(100, 100)}, toggle.Layout)
+	h.frame()
+	shadows := toggle.EnvironmentShadows()
+	if len(shadows) != 1 || !shadows[0].Inset {
+		t.Fatalf("switch draws environment shadows %+v, want the shadow inset", shadows)
+	}
+	if shadows[0].Color != outer.Color || shadows[0].Spread != outer.Spread {
+		t.Errorf("switch draws environment shadow %+v, want %+v", shadows[0], outer)
+	}
+	if toggle.EnvironmentShadow[0].Inset {
+		t.Error("EnvironmentShadow of the switch is changed")
+	}
+}
@@ -3,8 +3,11 @@ package freyja
 import (
 	"image"
 	"image/color"
+	"strings"
+	"unicode/utf8"
 
 	"gioui.org/font"
+	"gioui.org/io/semantic"
 	"gioui.org/layout"
 	"gioui.org/op"
 	"gioui.org/op/clip"
@@ -15,13 +18,14 @@ import (
 )
 
 type TextField struct {
-	Origin widget.Editor
+	Origin   widget.Editor
+	Disabled bool
 
 	LeadingContent  layout.Widget
 	TrailingContent layout.Widget
 	Spacing         unit.Dp
 
-	Shadow Shadow
+	Shadow Shadows
 
 	Background         color.NRGBA
 	BackgroundDisabled color.NRGBA
@@ -33,6 +37,10 @@ type TextField struct {
 
 	Inset layout.Inset
 
+	FocusRing FocusRing
+
+	// Deprecated: OutlineColor and OutlineWidth are drawn around the focused
+	// text field only if the color of FocusRing is transparent.
 	OutlineColor color.NRGBA
 	OutlineWidth unit.Dp
 
@@ -48,11 +56,24 @@ type TextField struct {
 	Hint              string
 	HintColor         color.NRGBA
 	HintColorDisabled color.NRGBA
+
+	hoverable
+
+	themed
 }
 
 func (t *TextField) Layout(gtx layout.Context) layout.Dimensions {
-	var disabled = gtx.Queue == nil
-	return layout.Stack{Alignment: layout.Center}.Layout(
+	if t.stale() {
+		t.theme.paintTextField(t)
+	}
+	t.trackHover(gtx)
+	content := gtx
+	gtx, disabled := disable(gtx, t.Disabled)
+	if disabled {
+		// Leading and trailing content is disabled along with the text field.
+		content = Disable(content)
+	}
+	dimensions := layout.Stack{Alignment: layout.Center}.Layout(
 		gtx,
 		layout.Expanded(
 			func(gtx layout.Context) layout.Dimensions {
@@ -60,37 +81,34 @@ func (t *TextField) Layout(gtx layout.Context) layout.Dimensions {
 					size  = gtx.Constraints.Min
 					shape = clip.UniformRRect(image.Rectangle{Max: size}, gtx.Dp(t.BorderRadius))
 				)
-				if t.Origin.Focused() {
-					var stroke = clip.Stroke{
+				background := func(gtx layout.Context) layout.Dimensions {
+					defer shape.Push(gtx.Ops).Pop()
+					if disabled {
+						paint.Fill(gtx.Ops, t.BackgroundDisabled)
+					} else {
+						paint.Fill(gtx.Ops, t.Background)
+					}
+					var border = clip.Stroke{
 						Path:  shape.Path(gtx.Ops),
-						Width: float32(gtx.Dp(t.OutlineWidth * 2)),
+						Width: float32(gtx.Dp(t.BorderWidth * 2)),
 					}
-					paint.FillShape(
-						gtx.Ops,
-						t.OutlineColor,
-						stroke.Op(),
-					)
-				} else {
-					t.Shadow.Layout(
-						gtx,
-						shape.Path(gtx.Ops),
-						func(gtx layout.Context) layout.Dimensions {
-							return layout.Dimensions{}
-						},
-					)
-				}
-				defer shape.Push(gtx.Ops).Pop()
-				if disabled {
-					paint.Fill(gtx.Ops, t.BackgroundDisabled)
-				} else {
-					paint.Fill(gtx.Ops, t.Background)
+					if disabled {
+						paint.FillShape(gtx.Ops, t.BorderColorDisabled, border.Op())
+					} else {
+						paint.FillShape(gtx.Ops, t.BorderColor, border.Op())
+					}
+					return layout.Dimensions{Size: size}
 				}
-				var border = clip.Stroke{
-					Path:  shape.Path(gtx.Ops),
-					Width: float32(gtx.Dp(t.BorderWidth * 2)),
+				// The background is the content of the shadow, so inset shadows are drawn over it.
+				dimensions := t.Shadow.LayoutRRect(gtx, shape, background)
+				if t.Origin.Focused() && !disabled {
+					ring := t.FocusRing
+					if ring.Color.A == 0 {
+						ring = FocusRing{Color: t.OutlineColor, Width: t.OutlineWidth}
+					}
+					ring.draw(gtx, image.Rectangle{Max: size}, gtx.Dp(t.BorderRadius))
 				}
-				paint.FillShape(gtx.Ops, t.BorderColor, border.Op())
-				return layout.Dimensions{Size: size}
+				return dimensions
 			},
 		),
 		layout.Stacked(
@@ -111,7 +129,8 @@ func (t *TextField) Layout(gtx layout.Context) layout.Dimensions {
 							layout.Rigid(
 								func(gtx layout.Context) layout.Dimensions {
 									if t.LeadingContent != nil {
-										return t.LeadingContent(gtx)
+										content.Constraints = gtx.Constraints
+										return t.LeadingContent(content)
 									}
 									return layout.Dimensions{}
 								},
@@ -159,6 +178,9 @@ func (t *TextField) Layout(gtx layout.Context) layout.Dimensions {
 											hintColor,
 										)
 									}
+									if disabled {
+										return t.text(gtx, textColor)
+									}
 									return t.Origin.Layout(
 										gtx,
 										t.Shaper,
@@ -180,7 +202,8 @@ func (t *TextField) Layout(gtx layout.Context) layout.Dimensions {
 							layout.Rigid(
 								func(gtx layout.Context) layout.Dimensions {
 									if t.TrailingContent != nil {
-										return t.TrailingContent(gtx)
+										content.Constraints = gtx.Constraints
+										return t.TrailingContent(content)
 									}
 									return layout.Dimensions{}
 								},
@@ -191,4 +214,27 @@ func (t *TextField) Layout(gtx layout.Context) layout.Dimensions {
 			},
 		),
 	)
+	t.addHoverArea(gtx.Ops, dimensions.Size)
+	return dimensions
+}
+
+// text lays the text of the editor out like the editor does. An editor takes
+// the focus even without a queue, so it's replaced by the text while disabled.
+func (t *TextField) text(gtx layout.Context, color op.CallOp) layout.Dimensions {
+	text := t.Origin.Text()
+	if t.Origin.Mask != 0 {
+		text = strings.Repeat(string(t.Origin.Mask), utf8.RuneCountInString(text))
+	}
+	label := widget.Label{Alignment: t.Origin.Alignment}
+	if t.Origin.SingleLine {
+		label.MaxLines = 1
+	}
+	record := op.Record(gtx.Ops)
+	dimensions := label.Layout(gtx, t.Shaper, t.Font, t.FontSize, text, color)
+	call := record.Stop()
+	defer clip.Rect{Max: dimensions.Size}.Push(gtx.Ops).Pop()
+	semantic.Editor.Add(gtx.Ops)
+	semantic.DisabledOp(true).Add(gtx.Ops)
+	call.Add(gtx.Ops)
+	return dimensions
 }
@@ -0,0 +1,611 @@
+package freyja
+
+import (
+	"image/color"
+	"time"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"github.com/widetape/freyja/pkg/freyja/anim"
+)
+
+// Palette is the set of colors a Theme builds widgets from.
+type Palette struct {
+	Primary   color.NRGBA // Primary is used to fill important interactive parts like push buttons and tints.
+	OnPrimary color.NRGBA // OnPrimary is used for content drawn on top of Primary.
+
+	Tonal   color.NRGBA // Tonal is a muted variant of Primary used to fill tonal push buttons.
+	OnTonal color.NRGBA // OnTonal is used for content drawn on top of Tonal.
+
+	Surface   color.NRGBA // Surface is the background of switches, radio buttons, sliders and text fields.
+	OnSurface color.NRGBA // OnSurface is used for text drawn on top of Surface.
+	Hint      color.NRGBA // Hint is used for hints and other secondary text.
+	Outline   color.NRGBA // Outline is used for borders and outlines.
+	Knob      color.NRGBA // Knob is used to fill knobs of switches and sliders.
+
+	Disabled   color.NRGBA // Disabled is used instead of backgrounds in disabled mode.
+	OnDisabled color.NRGBA // OnDisabled is used instead of content colors in disabled mode.
+
+	Selection color.NRGBA // Selection is used to highlight selected text.
+	Focus     color.NRGBA // Focus is the color of focus rings.
+	Hover     color.NRGBA // Hover is drawn over widgets while they are hovered.
+	Press     color.NRGBA // Press is drawn over widgets while they are being pressed.
+}
+
+// Variant selects one of the palettes of a Theme.
+type Variant uint8
+
+const (
+	Light Variant = iota // Light selects the light palette.
+	Dark                 // Dark selects the dark palette.
+)
+
+// Theme is the single place to configure the look of freyja widgets.
+//
+// Widgets built by a Theme are fully configured copies, so the fields
+// of a built widget can still be adjusted one by one. A built widget keeps
+// following its theme though: after SetVariant it re-resolves its colors from
+// the active palette on the next Layout. Colors set by hand are kept.
+type Theme struct {
+	Light Palette // Light is the palette of the Light variant.
+	Dark  Palette // Dark is the palette of the Dark variant.
+
+	Shaper   *text.Shaper // Shaper is used to layout the text.
+	Font     font.Font    // Font is used for the text.
+	TextSize unit.Sp      // TextSize is the size of the text.
+
+	CornerRadius unit.Dp // CornerRadius is the radius of push buttons and text fields.
+
+	ShadowColor color.NRGBA // ShadowColor is the color of the shadows of elevations.
+	Shadow      Shadows     // Shadow is casted by text fields.
+	KnobShadow  Shadows     // KnobShadow is casted by knobs of switches and sliders.
+	InsetShadow Shadows     // InsetShadow is casted into the track of switches.
+
+	FocusRing FocusRing // FocusRing is drawn around focused widgets, its color comes from the palette.
+
+	variant    Variant
+	generation int
+}
+
+// themed links a widget to the theme that built it.
+type themed struct {
+	theme      *Theme
+	generation int
+	palette    Palette // palette is a copy of the palette the widget was painted with.
+}
+
+// stale reports whether the theme has changed since the widget was painted.
+func (w *themed) stale() bool {
+	return w.theme != nil && w.theme.generation != w.generation
+}
+
+// repaint links the widget to the active palette of the theme. It returns
+// the palette the widget was painted with before, which is transparent
+// for a new widget, and the active one.
+func (w *themed) repaint(t *Theme) (old, new *Palette) {
+	previous := w.palette
+	*w = themed{theme: t, generation: t.generation, palette: *t.Palette()}
+	return &previous, &w.palette
+}
+
+// recolor sets the field to the value resolved from the new palette,
+// unless the field was set by hand after it was resolved from the old one.
+func recolor[T comparable](field *T, old, new T) {
+	if *field == old {
+		*field = new
+	}
+}
+
+// LightPalette is the palette used by NewTheme for the Light variant.
+var LightPalette = Palette{
+	Primary:   color.NRGBA{R: 0x2F, G: 0x6F, B: 0xEB, A: 0xFF},
+	OnPrimary: color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF},
+
+	Tonal:   color.NRGBA{R: 0xDC, G: 0xE6, B: 0xFB, A: 0xFF},
+	OnTonal: color.NRGBA{R: 0x1A, G: 0x3E, B: 0x86, A: 0xFF},
+
+	Surface:   color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF},
+	OnSurface: color.NRGBA{R: 0x1C, G: 0x1C, B: 0x1E, A: 0xFF},
+	Hint:      color.NRGBA{R: 0x8E, G: 0x8E, B: 0x93, A: 0xFF},
+	Outline:   color.NRGBA{R: 0xC7, G: 0xC7, B: 0xCC, A: 0xFF},
+	Knob:      color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF},
+
+	Disabled:   color.NRGBA{R: 0xE5, G: 0xE5, B: 0xEA, A: 0xFF},
+	OnDisabled: color.NRGBA{R: 0xAE, G: 0xAE, B: 0xB2, A: 0xFF},
+
+	Selection: color.NRGBA{R: 0x2F, G: 0x6F, B: 0xEB, A: 0x40},
+	Focus:     color.NRGBA{R: 0x2F, G: 0x6F, B: 0xEB, A: 0x80},
+	Hover:     color.NRGBA{A: 0x10},
+	Press:     color.NRGBA{A: 0x20},
+}
+
+// DarkPalette is the palette used by NewTheme for the Dark variant.
+var DarkPalette = Palette{
+	Primary:   color.NRGBA{R: 0x4C, G: 0x8D, B: 0xFF, A: 0xFF},
+	OnPrimary: color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF},
+
+	Tonal:   color.NRGBA{R: 0x2A, G: 0x3A, B: 0x5C, A: 0xFF},
+	OnTonal: color.NRGBA{R: 0xD6, G: 0xE3, B: 0xFF, A: 0xFF},
+
+	Surface:   color.NRGBA{R: 0x2C, G: 0x2C, B: 0x2E, A: 0xFF},
+	OnSurface: color.NRGBA{R: 0xF2, G: 0xF2, B: 0xF7, A: 0xFF},
+	Hint:      color.NRGBA{R: 0x8E, G: 0x8E, B: 0x93, A: 0xFF},
+	Outline:   color.NRGBA{R: 0x48, G: 0x48, B: 0x4A, A: 0xFF},
+	Knob:      color.NRGBA{R: 0xF2, G: 0xF2, B: 0xF7, A: 0xFF},
+
+	Disabled:   color.NRGBA{R: 0x3A, G: 0x3A, B: 0x3C, A: 0xFF},
+	OnDisabled: color.NRGBA{R: 0x63, G: 0x63, B: 0x66, A: 0xFF},
+
+	Selection: color.NRGBA{R: 0x4C, G: 0x8D, B: 0xFF, A: 0x60},
+	Focus:     color.NRGBA{R: 0x4C, G: 0x8D, B: 0xFF, A: 0xA0},
+	Hover:     color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0x10},
+	Press:     color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0x20},
+}
+
+// NewTheme returns a theme in the Light variant with LightPalette, DarkPalette
+// and default metrics that uses the shaper and the font for text.
+func NewTheme(shaper *text.Shaper, font font.Font) *Theme {
+	shadowColor := color.NRGBA{A: 0xFF}
+	return &Theme{
+		Light: LightPalette,
+		Dark:  DarkPalette,
+
+		Shaper:   shaper,
+		Font:     font,
+		TextSize: unit.Sp(14),
+
+		CornerRadius: unit.Dp(8),
+
+		ShadowColor: shadowColor,
+		Shadow:      Elevation(1).Shadows(shadowColor),
+		KnobShadow:  Elevation(1).Shadows(shadowColor),
+		InsetShadow: Shadows{{
+			Color:  color.NRGBA{A: 0x40},
+			Layers: 3,
+			Spread: unit.Dp(2),
+			Slope:  1,
+			Inset:  true,
+		}},
+
+		FocusRing: FocusRing{
+			Width:  unit.Dp(2),
+			Offset: unit.Dp(2),
+		},
+	}
+}
+
+// Variant returns the active variant of the theme.
+func (t *Theme) Variant() Variant {
+	return t.variant
+}
+
+// SetVariant activates the variant. Widgets built by the theme re-resolve
+// their colors on the next Layout, so the window only has to be invalidated.
+//
+// SetVariant should also be called after changing the active palette.
+func (t *Theme) SetVariant(v Variant) {
+	t.variant = v
+	t.generation++
+}
+
+// Palette returns the palette of the active variant.
+func (t *Theme) Palette() *Palette {
+	if t.variant == Dark {
+		return &t.Dark
+	}
+	return &t.Light
+}
+
+// PushButton returns a filled push button with the label.
+func (t *Theme) PushButton(label string) PushButton {
+	b := PushButton{
+		CornerRadius: t.CornerRadius,
+		OutlineWidth: unit.Dp(1),
+
+		Elevation:      1,
+		HoverElevation: 2,
+		PressElevation: 1,
+		ShadowColor:    t.ShadowColor,
+
+		FocusRing: t.FocusRing,
+
+		Inset: layout.Inset{
+			Top: unit.Dp(8), Bottom: unit.Dp(8),
+			Left: unit.Dp(16), Right: unit.Dp(16),
+		},
+
+		Shaper: t.Shaper,
+		Font:   t.Font,
+
+		Label:    label,
+		FontSize: t.TextSize,
+
+		IconSize:    unit.Dp(18),
+		IconSpacing: unit.Dp(8),
+
+		FadeDuration:   100 * time.Millisecond,
+		RippleDuration: 400 * time.Millisecond,
+
+		Spinner: Spinner{
+			Width:  unit.Dp(2),
+			Period: 800 * time.Millisecond,
+		},
+	}
+	t.paintPushButton(&b)
+	return b
+}
+
+// TonalPushButton returns a tonal push button with the label.
+func (t *Theme) TonalPushButton(label string) PushButton {
+	return t.emphasizedPushButton(label, Tonal)
+}
+
+// OutlinedPushButton returns an outlined push button with the label.
+func (t *Theme) OutlinedPushButton(label string) PushButton {
+	return t.emphasizedPushButton(label, Outlined)
+}
+
+// TextPushButton returns a text push button with the label.
+func (t *Theme) TextPushButton(label string) PushButton {
+	return t.emphasizedPushButton(label, TextOnly)
+}
+
+// emphasizedPushButton returns a push button with the label and the emphasis that rests flat.
+func (t *Theme) emphasizedPushButton(label string, emphasis Emphasis) PushButton {
+	b := t.PushButton(label)
+	b.Emphasis = emphasis
+	b.Elevation, b.HoverElevation, b.PressElevation = 0, 0, 0
+	if emphasis == Tonal {
+		b.HoverElevation = 1
+	}
+	t.paintPushButton(&b)
+	return b
+}
+
+// paintPushButton resolves the colors of the push button from the active palette and its emphasis.
+func (t *Theme) paintPushButton(b *PushButton) {
+	o, p := b.repaint(t)
+	var (
+		oldBackground, oldForeground, oldOutline = emphasisColors(o, b.emphasis)
+		background, foreground, outline          = emphasisColors(p, b.Emphasis)
+	)
+	recolor(&b.Background, fill(oldBackground), fill(background))
+	recolor(&b.BackgroundDisabled, fill(unless(oldBackground, o.Disabled)), fill(unless(background, p.Disabled)))
+	recolor(&b.Outline, fill(oldOutline), fill(outline))
+	recolor(&b.OutlineDisabled, fill(unless(oldOutline, o.Disabled)), fill(unless(outline, p.Disabled)))
+	recolor(&b.Foreground, fill(oldForeground), fill(foreground))
+	recolor(&b.ForegroundDisabled, fill(o.OnDisabled), fill(p.OnDisabled))
+	recolor(&b.IconColor, oldForeground, foreground)
+	recolor(&b.IconColorDisabled, o.OnDisabled, p.OnDisabled)
+	recolor(&b.HoverColor, o.Hover, p.Hover)
+	recolor(&b.ClickColor, o.Press, p.Press)
+	recolor(&b.RippleColor, o.Press, p.Press)
+	recolor(&b.FocusRing.Color, o.Focus, p.Focus)
+	b.emphasis = b.Emphasis
+}
+
+// emphasisColors returns the colors of a push button with the emphasis in the palette.
+// Transparent colors are not drawn.
+func emphasisColors(p *Palette, e Emphasis) (background, foreground, outline color.NRGBA) {
+	switch e {
+	case Filled:
+		return p.Primary, p.OnPrimary, color.NRGBA{}
+	case Tonal:
+		return p.Tonal, p.OnTonal, color.NRGBA{}
+	case Outlined:
+		return color.NRGBA{}, p.Primary, p.Outline
+	default:
+		return color.NRGBA{}, p.Primary, color.NRGBA{}
+	}
+}
+
+// unless returns the disabled color, unless the color it replaces is transparent.
+func unless(c, disabled color.NRGBA) color.NRGBA {
+	if c.A == 0 {
+		return color.NRGBA{}
+	}
+	return disabled
+}
+
+// Switch returns a switch.
+func (t *Theme) Switch() Switch {
+	s := Switch{
+		EnvironmentShadow: t.InsetShadow,
+		KnobShadow:        t.KnobShadow,
+
+		FocusRing: t.FocusRing,
+
+		KnobSize: unit.Dp(20),
+
+		Inset: unit.Dp(2),
+		Shift: unit.Dp(20),
+
+		Duration: 150 * time.Millisecond,
+		Easing:   anim.EaseInOut,
+	}
+	t.paintSwitch(&s)
+	return s
+}
+
+// LabeledSwitch returns a switch with the label after it.
+func (t *Theme) LabeledSwitch(label string) Switch {
+	s := t.Switch()
+	s.Label = t.controlLabel(label)
+	return s
+}
+
+// paintSwitch resolves the colors of the switch from the active palette.
+func (t *Theme) paintSwitch(s *Switch) {
+	o, p := s.repaint(t)
+	recolor(&s.Background, fill(o.Surface), fill(p.Surface))
+	recolor(&s.BackgroundDisabled, fill(o.Disabled), fill(p.Disabled))
+	recolor(&s.TintColor, o.Primary, p.Primary)
+	recolor(&s.TintColorDisabled, o.OnDisabled, p.OnDisabled)
+	recolor(&s.Knob, fill(o.Knob), fill(p.Knob))
+	recolor(&s.KnobDisabled, fill(o.Surface), fill(p.Surface))
+	recolor(&s.Label.Foreground, fill(o.OnSurface), fill(p.OnSurface))
+	recolor(&s.Label.ForegroundDisabled, fill(o.OnDisabled), fill(p.OnDisabled))
+	recolor(&s.FocusRing.Color, o.Focus, p.Focus)
+}
+
+// Checkbox returns an unchecked checkbox.
+func (t *Theme) Checkbox() Checkbox {
+	c := Checkbox{
+		OutlineWidth: unit.Dp(1),
+		MarkWidth:    unit.Dp(2),
+
+		Size:         unit.Dp(18),
+		CornerRadius: unit.Dp(4),
+
+		FocusRing: t.FocusRing,
+
+		Duration: 150 * time.Millisecond,
+		Easing:   anim.EaseOut,
+	}
+	t.paintCheckbox(&c)
+	return c
+}
+
+// LabeledCheckbox returns an unchecked checkbox with the label after it.
+func (t *Theme) LabeledCheckbox(label string) Checkbox {
+	c := t.Checkbox()
+	c.Label = t.controlLabel(label)
+	return c
+}
+
+// paintCheckbox resolves the colors of the checkbox from the active palette.
+func (t *Theme) paintCheckbox(c *Checkbox) {
+	o, p := c.repaint(t)
+	recolor(&c.Background, fill(o.Surface), fill(p.Surface))
+	recolor(&c.BackgroundDisabled, fill(o.Disabled), fill(p.Disabled))
+	recolor(&c.Outline, fill(o.Outline), fill(p.Outline))
+	recolor(&c.OutlineDisabled, fill(o.OnDisabled), fill(p.OnDisabled))
+	recolor(&c.Tint, fill(o.Primary), fill(p.Primary))
+	recolor(&c.TintDisabled, fill(o.OnDisabled), fill(p.OnDisabled))
+	recolor(&c.Mark, fill(o.OnPrimary), fill(p.OnPrimary))
+	recolor(&c.MarkDisabled, fill(o.Disabled), fill(p.Disabled))
+	recolor(&c.Label.Foreground, fill(o.OnSurface), fill(p.OnSurface))
+	recolor(&c.Label.ForegroundDisabled, fill(o.OnDisabled), fill(p.OnDisabled))
+	recolor(&c.FocusRing.Color, o.Focus, p.Focus)
+}
+
+// RadioButton returns a radio button for the key in the group.
+func (t *Theme) RadioButton(group *widget.Enum, key string) RadioButton {
+	b := RadioButton{
+		Group: group,
+		Key:   key,
+
+		OutlineWidth: unit.Dp(1),
+
+		Inset: unit.Dp(5),
+
+		KnobSize: unit.Dp(8),
+
+		FocusRing: t.FocusRing,
+	}
+	t.paintRadioButton(&b)
+	return b
+}
+
+// LabeledRadioButton returns a radio button for the key in the group with the label after it.
+func (t *Theme) LabeledRadioButton(group *widget.Enum, key, label string) RadioButton {
+	b := t.RadioButton(group, key)
+	b.Label = t.controlLabel(label)
+	return b
+}
+
+// paintRadioButton resolves the colors of the radio button from the active palette.
+func (t *Theme) paintRadioButton(b *RadioButton) {
+	o, p := b.repaint(t)
+	recolor(&b.Background, fill(o.Surface), fill(p.Surface))
+	recolor(&b.BackgroundDisabled, fill(o.Disabled), fill(p.Disabled))
+	recolor(&b.Outline, fill(o.Outline), fill(p.Outline))
+	recolor(&b.OutlineDisabled, fill(o.OnDisabled), fill(p.OnDisabled))
+	recolor(&b.Tint, fill(o.Primary), fill(p.Primary))
+	recolor(&b.Knob, fill(o.Knob), fill(p.Knob))
+	recolor(&b.KnobDisabled, fill(o.OnDisabled), fill(p.OnDisabled))
+	recolor(&b.Label.Foreground, fill(o.OnSurface), fill(p.OnSurface))
+	recolor(&b.Label.ForegroundDisabled, fill(o.OnDisabled), fill(p.OnDisabled))
+	recolor(&b.FocusRing.Color, o.Focus, p.Focus)
+}
+
+// controlLabel returns the label of a switch, a radio button or a checkbox.
+// Its colors are resolved from the active palette like the paint functions
+// of the controls do, so they keep following the theme.
+func (t *Theme) controlLabel(text string) ControlLabel {
+	p := t.Palette()
+	return ControlLabel{
+		Text: text,
+
+		Shaper:   t.Shaper,
+		Font:     t.Font,
+		FontSize: t.TextSize,
+
+		Foreground:         fill(p.OnSurface),
+		ForegroundDisabled: fill(p.OnDisabled),
+
+		Spacing: unit.Dp(8),
+	}
+}
+
+// RadioGroup returns a vertical radio group of labeled radio buttons for the options.
+func (t *Theme) RadioGroup(options ...RadioOption) RadioGroup {
+	g := RadioGroup{
+		Buttons: make([]RadioButton, len(options)),
+		Axis:    layout.Vertical,
+		Spacing: unit.Dp(8),
+	}
+	for i, o := range options {
+		g.Buttons[i] = t.LabeledRadioButton(nil, o.Key, o.Label)
+	}
+	return g
+}
+
+// SegmentedControl returns a segmented control in single selection mode with segments for the options.
+func (t *Theme) SegmentedControl(options ...RadioOption) SegmentedControl {
+	c := SegmentedControl{
+		Segments: make([]Segment, len(options)),
+
+		CornerRadius: t.CornerRadius,
+
+		DividerWidth: unit.Dp(1),
+
+		FocusRing: t.FocusRing,
+
+		Inset: layout.Inset{
+			Top: unit.Dp(6), Bottom: unit.Dp(6),
+			Left: unit.Dp(12), Right: unit.Dp(12),
+		},
+
+		Shaper:   t.Shaper,
+		Font:     t.Font,
+		FontSize: t.TextSize,
+
+		FadeDuration: 100 * time.Millisecond,
+
+		Duration: 200 * time.Millisecond,
+		Easing:   anim.EaseInOut,
+	}
+	for i, o := range options {
+		c.Segments[i].Key = o.Key
+		c.Segments[i].Label = o.Label
+	}
+	t.paintSegmentedControl(&c)
+	return c
+}
+
+// paintSegmentedControl resolves the colors of the segmented control from the active palette.
+func (t *Theme) paintSegmentedControl(c *SegmentedControl) {
+	o, p := c.repaint(t)
+	recolor(&c.Background, fill(o.Surface), fill(p.Surface))
+	recolor(&c.BackgroundDisabled, fill(o.Disabled), fill(p.Disabled))
+	recolor(&c.Tint, fill(o.Primary), fill(p.Primary))
+	recolor(&c.TintDisabled, fill(o.OnDisabled), fill(p.OnDisabled))
+	recolor(&c.Divider, o.Outline, p.Outline)
+	recolor(&c.Foreground, fill(o.OnSurface), fill(p.OnSurface))
+	recolor(&c.ForegroundSelected, fill(o.OnPrimary), fill(p.OnPrimary))
+	recolor(&c.ForegroundDisabled, fill(o.OnDisabled), fill(p.OnDisabled))
+	recolor(&c.HoverColor, o.Hover, p.Hover)
+	recolor(&c.ClickColor, o.Press, p.Press)
+	recolor(&c.FocusRing.Color, o.Focus, p.Focus)
+}
+
+// Slider returns a slider.
+func (t *Theme) Slider() Slider {
+	s := Slider{
+		BackgroundWidth: unit.Dp(4),
+
+		KnobSize:   unit.Dp(20),
+		KnobShadow: t.KnobShadow,
+
+		FocusRing: t.FocusRing,
+
+		TickLength: unit.Dp(4),
+
+		Bubble: SliderBubble{
+			CornerRadius: unit.Dp(4),
+			Inset: layout.Inset{
+				Top: unit.Dp(4), Bottom: unit.Dp(4),
+				Left: unit.Dp(8), Right: unit.Dp(8),
+			},
+			Gap:          unit.Dp(6),
+			Shadow:       Elevation(2).Shadows(t.ShadowColor),
+			FadeDuration: 150 * time.Millisecond,
+		},
+
+		Shaper:   t.Shaper,
+		Font:     t.Font,
+		FontSize: t.TextSize,
+	}
+	t.paintSlider(&s)
+	return s
+}
+
+// RangeSlider returns a range slider.
+func (t *Theme) RangeSlider() RangeSlider {
+	return RangeSlider{Slider: t.Slider()}
+}
+
+// paintSlider resolves the colors of the slider from the active palette.
+func (t *Theme) paintSlider(s *Slider) {
+	o, p := s.repaint(t)
+	recolor(&s.Background, o.Outline, p.Outline)
+	recolor(&s.BackgroundDisabled, o.Disabled, p.Disabled)
+	recolor(&s.Knob, o.Knob, p.Knob)
+	recolor(&s.KnobDisabled, o.Disabled, p.Disabled)
+	recolor(&s.Tint, o.Primary, p.Primary)
+	recolor(&s.TintDisabled, o.OnDisabled, p.OnDisabled)
+	recolor(&s.TickColor, o.Hint, p.Hint)
+	recolor(&s.TickColorDisabled, o.OnDisabled, p.OnDisabled)
+	recolor(&s.Bubble.Background, o.OnSurface, p.OnSurface)
+	recolor(&s.Bubble.Foreground, o.Surface, p.Surface)
+	recolor(&s.FocusRing.Color, o.Focus, p.Focus)
+}
+
+// TextField returns a single line text field with the hint.
+func (t *Theme) TextField(hint string) TextField {
+	f := TextField{
+		Origin: widget.Editor{SingleLine: true},
+
+		Spacing: unit.Dp(8),
+
+		Shadow: t.Shadow,
+
+		BorderWidth:  unit.Dp(1),
+		BorderRadius: t.CornerRadius,
+
+		Inset: layout.Inset{
+			Top: unit.Dp(8), Bottom: unit.Dp(8),
+			Left: unit.Dp(12), Right: unit.Dp(12),
+		},
+
+		FocusRing: t.FocusRing,
+
+		Font:   t.Font,
+		Shaper: t.Shaper,
+
+		FontSize: t.TextSize,
+
+		Hint: hint,
+	}
+	t.paintTextField(&f)
+	return f
+}
+
+// paintTextField resolves the colors of the text field from the active palette.
+func (t *Theme) paintTextField(f *TextField) {
+	o, p := f.repaint(t)
+	recolor(&f.Background, o.Surface, p.Surface)
+	recolor(&f.BackgroundDisabled, o.Disabled, p.Disabled)
+	recolor(&f.BorderColor, o.Outline, p.Outline)
+	recolor(&f.BorderColorDisabled, o.Disabled, p.Disabled)
+	recolor(&f.FocusRing.Color, o.Focus, p.Focus)
+	recolor(&f.SelectionColor, o.Selection, p.Selection)
+	recolor(&f.FontColor, o.OnSurface, p.OnSurface)
+	recolor(&f.FontColorDisabled, o.OnDisabled, p.OnDisabled)
+	recolor(&f.HintColor, o.Hint, p.Hint)
+	recolor(&f.HintColorDisabled, o.OnDisabled, p.OnDisabled)
+}
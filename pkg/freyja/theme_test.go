@@ -0,0 +1,64 @@
+package freyja_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"gioui.org/font/gofont"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/text"
+	"github.com/widetape/freyja/pkg/freyja"
+)
+
+func ExampleTheme() {
+	fonts := gofont.Collection()
+	theme := freyja.NewTheme(text.NewShaper(fonts), fonts[0].Font)
+	gtx := layout.Context{}
+
+	// Colors are changed in one place before the widgets are built.
+	theme.Light.Primary = theme.Light.OnSurface
+
+	var (
+		button = theme.PushButton("Save")
+		toggle = theme.Switch()
+		field  = theme.TextField("Name")
+	)
+
+	// Every widget is fully configured, but still can be adjusted.
+	button.FontSize = theme.TextSize * 2
+
+	button.Layout(gtx)
+	toggle.Layout(gtx)
+	field.Layout(gtx)
+}
+
+func TestTheme_SetVariant(t *testing.T) {
+	theme := newTheme()
+	button := theme.PushButton("Label")
+	if button.HoverColor != freyja.LightPalette.Hover {
+		t.Fatalf("got hover color %v, want %v", button.HoverColor, freyja.LightPalette.Hover)
+	}
+	custom := color.NRGBA{R: 0xFF, A: 0x40}
+	button.ClickColor = custom
+
+	theme.SetVariant(freyja.Dark)
+	gtx := layout.Context{
+		Constraints: layout.Exact(image.Pt(100, 100)),
+		Ops:         new(op.Ops),
+	}
+	button.Layout(gtx)
+	if button.HoverColor != freyja.DarkPalette.Hover {
+		t.Fatalf("got hover color %v, want %v", button.HoverColor, freyja.DarkPalette.Hover)
+	}
+	if button.ClickColor != custom {
+		t.Errorf("got click color %v, want %v set by hand", button.ClickColor, custom)
+	}
+
+	theme.SetVariant(freyja.Light)
+	button.Layout(gtx)
+	if button.HoverColor != freyja.LightPalette.Hover || button.ClickColor != custom {
+		t.Errorf("got hover color %v and click color %v, want %v and %v", button.HoverColor, button.ClickColor, freyja.LightPalette.Hover, custom)
+	}
+}